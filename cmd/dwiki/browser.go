@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser opens the url in the default browser using the platform's opener command.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", url)
+	default:
+		// Without a display there is no browser to open
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return errors.New("no display available")
+		}
+
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	return cmd.Start()
+}
+
+// openOrPrint opens the url in the browser, printing it instead when no browser can be opened.
+func openOrPrint(ui uiMessages, url string) {
+	err := openBrowser(url)
+
+	if err != nil {
+		fmt.Printf(ui.openInBrowser+"\n", url)
+	}
+}
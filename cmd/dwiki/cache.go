@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheTTL is how long the CLI reuses a cached response.
+const cacheTTL = 24 * time.Hour
+
+// cacheDir returns the directory of the response cache, e.g. ~/.cache/dwiki on Linux.
+func cacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "dwiki"), nil
+}
+
+// clearCache removes every cached response.
+func clearCache() error {
+	dir, err := cacheDir()
+
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// config holds the defaults read from the config file. Command line flags override these values. Length is a
+// pointer so that an explicit 0, the full summary as with -length 0, can be told apart from a missing value.
+type config struct {
+	Language string `json:"language"`
+	Limit    int    `json:"limit"`
+	Length   *int   `json:"length"`
+	Format   string `json:"format"`
+}
+
+// configPath returns the location of the config file, e.g. ~/.config/dwiki/config.json on Linux.
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "dwiki", "config.json"), nil
+}
+
+// loadConfig reads the config file. A missing file is not an error and yields an empty config.
+func loadConfig() (config, error) {
+	var cfg config
+
+	path, err := configPath()
+
+	if err != nil {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return cfg, err
+	}
+
+	err = json.Unmarshal(data, &cfg)
+
+	if err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides the config with the DWIKI_LANG and DWIKI_LIMIT environment variables when they are set.
+func applyEnv(cfg *config) error {
+	if lang := os.Getenv("DWIKI_LANG"); lang != "" {
+		cfg.Language = lang
+	}
+
+	if limit := os.Getenv("DWIKI_LIMIT"); limit != "" {
+		n, err := strconv.Atoi(limit)
+
+		if err != nil {
+			return fmt.Errorf("invalid DWIKI_LIMIT %q: must be a number", limit)
+		}
+
+		cfg.Limit = n
+	}
+
+	return nil
+}
@@ -2,95 +2,496 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/dmars8047/dwiki/pkg/dwiki"
 )
 
+// isTerminal reports whether the given file is attached to a terminal rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 func main() {
 
 	var topic string
 
-	// Look for the -topic flag
-	if len(os.Args) > 1 {
-		if os.Args[1] == "-topic" || os.Args[1] == "--topic" || os.Args[1] == "-t" || os.Args[1] == "--t" {
-			// Get the rest of the arguments
-			topic = strings.Join(os.Args[2:], "_")
+	cfg, err := loadConfig()
+
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	// Environment variables take precedence over the config file
+	err = applyEnv(&cfg)
+
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	if cfg.Format == "" {
+		cfg.Format = "text"
+	}
+
+	defaultLength := dwiki.DefaultSummaryLength
+
+	if cfg.Length != nil {
+		defaultLength = *cfg.Length
+	}
+
+	// Flags override the values from the environment and the config file
+	flag.StringVar(&topic, "topic", "", "the topic to search for")
+	flag.StringVar(&topic, "t", "", "the topic to search for (shorthand)")
+	lang := flag.String("lang", cfg.Language, "the Wikipedia language edition to search, e.g. en or fr")
+	project := flag.String("project", "", "the Wikimedia project to search, e.g. wiktionary or wikiquote (default wikipedia)")
+	limit := flag.Int("limit", cfg.Limit, "the maximum number of search results to list")
+	length := flag.Int("length", defaultLength, "the maximum number of characters of the summary to print, 0 for the full summary")
+	format := flag.String("format", cfg.Format, "the output format of the summary: text or json")
+	templateText := flag.String("template", "", "a Go text/template for the text summary, e.g. '{{.Title}}: {{.URL}}'")
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "print the raw API responses to stderr")
+	flag.BoolVar(&debug, "raw", false, "print the raw API responses to stderr (alias of -debug)")
+	urlOnly := flag.Bool("url", false, "print only the URL of the top search result")
+	openURL := flag.Bool("open", false, "open the chosen article in the default browser")
+	saveDir := flag.String("save", "", "also save the chosen article as a markdown note in the given directory")
+	noCache := flag.Bool("no-cache", false, "don't read or store responses in the on-disk cache")
+	clearFlag := flag.Bool("clear-cache", false, "remove the on-disk cache and exit")
+	promptTimeout := flag.Int("prompt-timeout", 0, "the number of seconds to wait for the article number before giving up, 0 to wait forever")
+	onTimeout := flag.String("on-timeout", "first", "what to do when the article number prompt times out: first or abort")
+	listOptions := flag.Bool("options", false, "print the search results as JSON with their numbers, titles and page ids, and exit")
+	infobox := flag.Bool("infobox", false, "print a one-line digest of the article's infobox, e.g. its birth and death years, before the summary")
+	ndjson := flag.Bool("ndjson", false, "print the search results as newline-delimited JSON, one article per line, and exit")
+	csvOut := flag.Bool("csv", false, "print the search results as CSV with their titles, page ids, word counts, urls and descriptions, and exit")
+	choiceNumber := flag.Int("choice", 0, "the number of the search result to read, skipping the prompt")
+	langUI := flag.String("lang-ui", "en", "the language of the prompts: en, de, es or fr")
+	tuiMode := flag.Bool("tui", false, "browse search results in a full-screen terminal interface")
+	flag.Parse()
+
+	topic = joinTopic(topic, flag.Args())
+
+	ui, err := lookupUILanguage(*langUI)
+
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	if *clearFlag {
+		err = clearCache()
+
+		if err != nil {
+			fmt.Println(ui.errorText(err))
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if *format != "text" && *format != "json" {
+		fmt.Println(ui.invalidFormat)
+		return
+	}
+
+	// Check the template up front rather than after the search and selection
+	out := output{format: *format, open: *openURL, saveDir: *saveDir, project: *project, infobox: *infobox}
+
+	if *templateText != "" {
+		out.template, err = template.New("summary").Parse(*templateText)
+
+		if err != nil {
+			fmt.Printf(ui.invalidTemplate+"\n", err)
+			return
 		}
 	}
 
+	if *onTimeout != "first" && *onTimeout != "abort" {
+		fmt.Println(ui.invalidAction)
+		return
+	}
+
+	if *length < 0 {
+		fmt.Println(ui.invalidLength)
+		return
+	}
+
+	// The library disables truncation with a negative length rather than 0
+	summaryLength := *length
+
+	if summaryLength == 0 {
+		summaryLength = -1
+	}
+
+	client := &dwiki.Client{
+		Language:      *lang,
+		Project:       *project,
+		SearchLimit:   *limit,
+		SummaryLength: summaryLength,
+		MoreLinkLabel: ui.moreLink,
+		Messages:      ui.libraryMessages(),
+	}
+
+	if debug {
+		client.Debug = os.Stderr
+	}
+
+	// Repeated lookups are served from disk, even across runs, unless the cache is turned off
+	if dir, err := cacheDir(); err == nil && !*noCache {
+		client.Cache = dwiki.NewDiskCache(dir, cacheTTL)
+	}
+
+	ctx := context.Background()
+
+	// The TUI relies on stty, which Windows lacks, so the plain prompt is used there instead
+	if *tuiMode && runtime.GOOS == "windows" {
+		fmt.Fprintln(os.Stderr, ui.tuiUnsupported)
+	} else if *tuiMode {
+		err = runTUI(ctx, client, ui, topic)
+
+		if err != nil {
+			fmt.Println(ui.errorText(err))
+		}
+
+		return
+	}
+
+	// When stdin is piped the banners and prompts are suppressed so that only the results are written
+	interactive := isTerminal(os.Stdin)
+
+	// A single reader is shared so that buffered input (e.g. the selection) isn't lost between reads
+	reader := bufio.NewReader(os.Stdin)
+
 	if topic == "" {
-		fmt.Print("\nWelcome to the Wikipedia search tool!\n\n")
+		if interactive {
+			fmt.Printf("\n%s\n\n", ui.welcome)
+			fmt.Print(ui.topicPrompt)
+		}
+
 		// Get the topic from the user
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Printf("Enter the topic you want to search for: ")
 		topic, _ = reader.ReadString('\n')
 	}
 
 	topic = strings.TrimSpace(topic)
 
+	// Offer a random article rather than stopping at a blank topic, unless the input is scripted
+	if topic == "" && interactive && !*urlOnly {
+		fmt.Print(ui.randomOffer)
+
+		answer, _ := reader.ReadString('\n')
+
+		if ui.isYes(answer) {
+			fmt.Println()
+
+			article, err := client.GetRandomArticle(ctx)
+
+			if err != nil {
+				fmt.Println(ui.errorText(err))
+				return
+			}
+
+			printArticle(client, ui, article, out)
+			return
+		}
+	}
+
 	if topic == "" {
-		fmt.Println("Error. You must enter a topic to search for.")
+		fmt.Println(ui.noTopic)
 		return
 	}
 
-	fmt.Println()
+	if *urlOnly {
+		url, code := printTopURL(ctx, client, ui, topic)
+
+		// The url is already printed, so it isn't printed again when no browser can be opened
+		if *openURL && code == 0 {
+			openBrowser(url)
+		}
+
+		os.Exit(code)
+	}
+
+	if interactive {
+		fmt.Println()
+	}
+
+	// In JSON mode the result list goes to stderr so that stdout only contains the JSON document
+	var menu io.Writer = os.Stdout
+
+	if *format == "json" {
+		menu = os.Stderr
+	}
+
+	searchOpts := make([]dwiki.Option, 0)
+
+	// The CSV has a description column, which searches leave empty unless asked for
+	if *csvOut {
+		searchOpts = append(searchOpts, dwiki.WithDescriptions())
+	}
 
-	options, err := dwiki.GetMatchingArticles(topic, os.Stdout)
+	options, err := client.Search(ctx, topic, searchOpts...)
 
 	if err != nil {
-		fmt.Printf("Error: %s\n", err)
+		fmt.Println(ui.errorText(err))
+		return
+	}
+
+	// Wrapping programs read the options as JSON and pass the number back with -choice
+	if *listOptions {
+		printOptions(options)
+		return
+	}
+
+	if *ndjson {
+		err = dwiki.WriteNDJSON(options, os.Stdout)
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.errorText(err))
+		}
+
+		return
+	}
+
+	if *csvOut {
+		err = client.WriteResultsCSV(options, os.Stdout)
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.errorText(err))
+		}
+
 		return
 	}
 
 	if len(options) == 0 {
+		fmt.Fprintf(menu, "%s\n\n", ui.noResults)
 		return
 	}
 
-	fmt.Println()
+	choice := strconv.Itoa(*choiceNumber)
 
-	// Get the user's choice
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Enter the number of the article you want to read: ")
-	choice, _ := reader.ReadString('\n')
+	if *choiceNumber == 0 {
+		// Print the titles of the search results
+		fmt.Fprintln(menu, ui.searchResults)
+
+		for i, option := range options {
+			if option.WordCount > 0 {
+				readingTime := fmt.Sprintf(ui.readingTime, formatCount(int(option.ReadingTime().Minutes())))
+				fmt.Fprintf(menu, "%d. %s (%s)\n", i+1, option.Title, readingTime)
+			} else {
+				fmt.Fprintf(menu, "%d. %s\n", i+1, option.Title)
+			}
+		}
+
+		for {
+			// Get the user's choice
+			if interactive {
+				fmt.Fprintln(menu)
+				fmt.Fprint(menu, ui.choicePrompt)
+			}
+
+			var ok bool
+
+			choice, ok = readLine(reader, time.Duration(*promptTimeout)*time.Second)
+
+			if !ok {
+				if *onTimeout == "abort" {
+					fmt.Printf("\n%s\n", ui.noChoiceInTime)
+					return
+				}
+
+				// Read the first result, as with piped input without a selection
+				fmt.Fprintf(menu, "\n%s\n", ui.timedOut)
+				choice = "1"
+				break
+			}
+
+			// Part of a title is accepted in place of the number as long as it matches a single result
+			number, ambiguous := matchTitle(choice, options)
+
+			if ambiguous && interactive {
+				fmt.Fprintln(menu, ui.ambiguousChoice)
+				continue
+			}
+
+			if number > 0 {
+				choice = strconv.Itoa(number)
+			}
+
+			break
+		}
+	}
 
 	choice = strings.TrimSpace(choice)
 
+	// Piped input without a selection reads the first result
+	if choice == "" && !interactive {
+		choice = "1"
+	}
+
 	choiceInt := 0
 
 	// Convert the choice to an integer
 	if choice == "" {
-		fmt.Println("Error. You must enter a valid number.")
+		fmt.Println(ui.invalidNumber)
 		return
 	}
 
 	choiceInt, err = strconv.Atoi(choice)
 
 	if err != nil {
-		fmt.Println("Error. You must enter a valid number.")
+		fmt.Println(ui.invalidNumber)
 		return
 	}
 
-	fmt.Println()
-
-	selectedTitle, ok := options[choiceInt]
+	if interactive && *choiceNumber == 0 {
+		fmt.Fprintln(menu)
+	}
 
-	if !ok {
-		fmt.Println("Error. You must enter a valid number.")
+	if choiceInt < 1 || choiceInt > len(options) {
+		fmt.Println(ui.invalidNumber)
 		return
 	}
 
+	selected := options[choiceInt-1]
+
 	// Get the article summary
-	err = dwiki.GetArticleSummary(selectedTitle, os.Stdout)
+	article, err := client.GetArticleDetails(ctx, selected.PageID)
 
 	if err != nil {
-		fmt.Printf("Error: %s\n", err)
+		fmt.Println(ui.errorText(err))
 		return
 	}
 
-	fmt.Print("\n\n")
+	// The word count is only known from the search results
+	article.WordCount = selected.WordCount
+
+	printArticle(client, ui, article, out)
+}
+
+// output holds the options controlling how the chosen article is printed.
+type output struct {
+	format   string
+	template *template.Template
+	open     bool
+	saveDir  string
+	project  string
+	infobox  bool
+}
+
+// joinTopic returns the topic with the remaining command line arguments, the rest of a multi-word topic, appended.
+// Full-text search expects the words separated by spaces, so they are not joined with underscores as they would be
+// in a title.
+func joinTopic(topic string, args []string) string {
+	if topic == "" || len(args) == 0 {
+		return topic
+	}
+
+	return strings.Join(append([]string{topic}, args...), " ")
+}
+
+// printArticle prints the article in the chosen output format, using the template if one was given for the text
+// format, and, if requested, saves it as a note and opens it in the browser.
+func printArticle(client *dwiki.Client, ui uiMessages, article *dwiki.Article, out output) {
+	switch {
+	case out.format == "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(article)
+	case out.template != nil:
+		err := out.template.Execute(os.Stdout, article)
+
+		if err != nil {
+			fmt.Println(ui.errorText(err))
+			return
+		}
+	default:
+		// The digest is a decoration, so the summary is printed without it if the lookup fails
+		if out.infobox {
+			digest, err := client.GetInfoboxDigest(context.Background(), article.PageID)
+
+			if err == nil && digest != "" {
+				fmt.Printf("%s\n\n", digest)
+			}
+		}
+
+		fmt.Print(client.FormatSummary(article))
+		fmt.Print("\n\n")
+	}
+
+	if out.saveDir != "" {
+		path, err := saveNote(out.saveDir, out.project, article)
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.errorText(err))
+		} else {
+			fmt.Fprintf(os.Stderr, ui.savedTo+"\n", path)
+		}
+	}
+
+	if out.open {
+		openOrPrint(ui, article.URL)
+	}
+}
+
+// menuOption is a search result as printed by -options.
+type menuOption struct {
+	Num    int    `json:"num"`
+	Title  string `json:"title"`
+	PageID int    `json:"pageid"`
+}
+
+// printOptions prints the numbered search results as a JSON array, in the numbering -choice accepts.
+func printOptions(options []dwiki.Article) {
+	menu := make([]menuOption, 0, len(options))
+
+	for i, option := range options {
+		menu = append(menu, menuOption{Num: i + 1, Title: option.Title, PageID: option.PageID})
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(menu)
+}
+
+// printTopURL prints the URL of the top search result for the topic and returns it along with the process
+// exit code, which is non-zero when no article is found.
+func printTopURL(ctx context.Context, client *dwiki.Client, ui uiMessages, topic string) (string, int) {
+	options, err := client.Search(ctx, topic, dwiki.WithLimit(1))
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.errorText(err))
+		return "", 1
+	}
+
+	if len(options) == 0 {
+		fmt.Fprintln(os.Stderr, ui.noResults)
+		return "", 1
+	}
+
+	article, err := client.GetArticleDetails(ctx, options[0].PageID)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.errorText(err))
+		return "", 1
+	}
+
+	fmt.Println(article.URL)
 
+	return article.URL, 0
 }
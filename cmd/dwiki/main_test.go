@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestJoinTopic(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		args  []string
+		want  string
+	}{
+		{"single word", "golang", nil, "golang"},
+		{"multi-word topic", "machine", []string{"learning"}, "machine learning"},
+		{"quoted topic", "machine learning", nil, "machine learning"},
+		{"no topic", "", []string{"learning"}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := joinTopic(test.topic, test.args); got != test.want {
+				t.Errorf("joinTopic(%q, %q) = %q, want %q", test.topic, test.args, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dmars8047/dwiki/pkg/dwiki"
+)
+
+// uiMessages holds the text of the interactive prompts in one language.
+type uiMessages struct {
+	welcome         string
+	topicPrompt     string
+	randomOffer     string
+	yes             []string
+	noResults       string
+	searchResults   string
+	readingTime     string
+	choicePrompt    string
+	ambiguousChoice string
+	timedOut        string
+	moreLink        string
+
+	errorFormat     string
+	invalidFormat   string
+	invalidTemplate string
+	invalidAction   string
+	invalidLength   string
+	openInBrowser   string
+	invalidNumber   string
+	noTopic         string
+	noChoiceInTime  string
+	savedTo         string
+
+	// The TUI's messages
+	tuiUnsupported string
+	tuiNoTerminal  string
+	tuiNoStty      string
+	tuiSearch      string
+	tuiSearching   string
+	tuiLoading     string
+	tuiResults     string
+	tuiHelp        string
+}
+
+// uiLanguages maps the languages selectable with -lang-ui to their messages.
+var uiLanguages = map[string]uiMessages{
+	"en": {
+		welcome:         "Welcome to the Wikipedia search tool!",
+		topicPrompt:     "Enter the topic you want to search for: ",
+		randomOffer:     "No topic entered. Would you like to read a random article instead? [y/N]: ",
+		yes:             []string{"y", "yes"},
+		noResults:       dwiki.DefaultMessages.NoResults,
+		searchResults:   dwiki.DefaultMessages.SearchResults,
+		readingTime:     "~%s min read",
+		choicePrompt:    dwiki.DefaultMessages.ChoicePrompt,
+		ambiguousChoice: "Several results match that title, enter more of it or the number.",
+		timedOut:        "No article number was entered in time, reading the first result.",
+		moreLink:        dwiki.DefaultMoreLinkLabel,
+		errorFormat:     "Error: %s",
+		invalidFormat:   "Error. The output format must be text or json.",
+		invalidTemplate: "Error. Invalid template: %s",
+		invalidAction:   "Error. The timeout action must be first or abort.",
+		invalidLength:   "Error. The summary length must be 0 or more.",
+		openInBrowser:   "Open in your browser: %s",
+		invalidNumber:   "Error. You must enter a valid number.",
+		noTopic:         "Error. You must enter a topic to search for.",
+		noChoiceInTime:  "Error. No article number was entered in time.",
+		savedTo:         "Saved to %s",
+		tuiUnsupported:  "The TUI isn't supported on Windows, using the prompt instead.",
+		tuiNoTerminal:   "the TUI requires an interactive terminal",
+		tuiNoStty:       "the TUI requires a terminal that supports stty",
+		tuiSearch:       "Search:",
+		tuiSearching:    "Searching...",
+		tuiLoading:      "Loading...",
+		tuiResults:      "%s results",
+		tuiHelp:         "Enter: search/read  Tab: switch focus  Up/Down: select  Esc: quit",
+	},
+	"de": {
+		welcome:         "Willkommen beim Wikipedia-Suchwerkzeug!",
+		topicPrompt:     "Geben Sie das Thema ein, nach dem Sie suchen möchten: ",
+		randomOffer:     "Kein Thema eingegeben. Möchten Sie stattdessen einen zufälligen Artikel lesen? [j/N]: ",
+		yes:             []string{"j", "ja"},
+		noResults:       "Keine Suchergebnisse gefunden.",
+		searchResults:   "Suchergebnisse:",
+		readingTime:     "~%s Min. Lesezeit",
+		choicePrompt:    "Geben Sie die Nummer des Artikels ein, den Sie lesen möchten: ",
+		ambiguousChoice: "Mehrere Ergebnisse passen zu diesem Titel, geben Sie mehr davon oder die Nummer ein.",
+		timedOut:        "Es wurde keine Nummer rechtzeitig eingegeben, das erste Ergebnis wird gelesen.",
+		moreLink:        "Mehr erfahren",
+		errorFormat:     "Fehler: %s",
+		invalidFormat:   "Fehler. Das Ausgabeformat muss text oder json sein.",
+		invalidTemplate: "Fehler. Ungültige Vorlage: %s",
+		invalidAction:   "Fehler. Die Aktion bei Zeitüberschreitung muss first oder abort sein.",
+		invalidLength:   "Fehler. Die Länge der Zusammenfassung muss 0 oder größer sein.",
+		openInBrowser:   "Im Browser öffnen: %s",
+		invalidNumber:   "Fehler. Sie müssen eine gültige Nummer eingeben.",
+		noTopic:         "Fehler. Sie müssen ein Thema für die Suche eingeben.",
+		noChoiceInTime:  "Fehler. Es wurde keine Artikelnummer rechtzeitig eingegeben.",
+		savedTo:         "Gespeichert unter %s",
+		tuiUnsupported:  "Die TUI wird unter Windows nicht unterstützt, stattdessen wird die Eingabeaufforderung verwendet.",
+		tuiNoTerminal:   "die TUI erfordert ein interaktives Terminal",
+		tuiNoStty:       "die TUI erfordert ein Terminal, das stty unterstützt",
+		tuiSearch:       "Suche:",
+		tuiSearching:    "Suche läuft...",
+		tuiLoading:      "Wird geladen...",
+		tuiResults:      "%s Ergebnisse",
+		tuiHelp:         "Eingabe: suchen/lesen  Tab: Fokus wechseln  Auf/Ab: auswählen  Esc: beenden",
+	},
+	"es": {
+		welcome:         "¡Bienvenido a la herramienta de búsqueda de Wikipedia!",
+		topicPrompt:     "Introduzca el tema que desea buscar: ",
+		randomOffer:     "No se introdujo ningún tema. ¿Desea leer un artículo aleatorio? [s/N]: ",
+		yes:             []string{"s", "si", "sí"},
+		noResults:       "No se encontraron resultados.",
+		searchResults:   "Resultados de la búsqueda:",
+		readingTime:     "~%s min de lectura",
+		choicePrompt:    "Introduzca el número del artículo que desea leer: ",
+		ambiguousChoice: "Varios resultados coinciden con ese título; introduzca más de él o el número.",
+		timedOut:        "No se introdujo ningún número a tiempo; se muestra el primer resultado.",
+		moreLink:        "Más información",
+		errorFormat:     "Error: %s",
+		invalidFormat:   "Error. El formato de salida debe ser text o json.",
+		invalidTemplate: "Error. Plantilla no válida: %s",
+		invalidAction:   "Error. La acción al agotarse el tiempo debe ser first o abort.",
+		invalidLength:   "Error. La longitud del resumen debe ser 0 o más.",
+		openInBrowser:   "Abrir en el navegador: %s",
+		invalidNumber:   "Error. Debe introducir un número válido.",
+		noTopic:         "Error. Debe introducir un tema para buscar.",
+		noChoiceInTime:  "Error. No se introdujo ningún número de artículo a tiempo.",
+		savedTo:         "Guardado en %s",
+		tuiUnsupported:  "La TUI no es compatible con Windows; se usa el indicador en su lugar.",
+		tuiNoTerminal:   "la TUI requiere un terminal interactivo",
+		tuiNoStty:       "la TUI requiere un terminal compatible con stty",
+		tuiSearch:       "Buscar:",
+		tuiSearching:    "Buscando...",
+		tuiLoading:      "Cargando...",
+		tuiResults:      "%s resultados",
+		tuiHelp:         "Intro: buscar/leer  Tab: cambiar el foco  Arriba/Abajo: seleccionar  Esc: salir",
+	},
+	"fr": {
+		welcome:         "Bienvenue dans l'outil de recherche Wikipédia !",
+		topicPrompt:     "Entrez le sujet que vous voulez rechercher : ",
+		randomOffer:     "Aucun sujet saisi. Voulez-vous lire un article au hasard ? [o/N] : ",
+		yes:             []string{"o", "oui"},
+		noResults:       "Aucun résultat trouvé.",
+		searchResults:   "Résultats de la recherche :",
+		readingTime:     "~%s min de lecture",
+		choicePrompt:    "Entrez le numéro de l'article que vous voulez lire : ",
+		ambiguousChoice: "Plusieurs résultats correspondent à ce titre, saisissez-en davantage ou le numéro.",
+		timedOut:        "Aucun numéro saisi à temps, lecture du premier résultat.",
+		moreLink:        "En savoir plus",
+		errorFormat:     "Erreur : %s",
+		invalidFormat:   "Erreur. Le format de sortie doit être text ou json.",
+		invalidTemplate: "Erreur. Modèle invalide : %s",
+		invalidAction:   "Erreur. L'action en cas de délai dépassé doit être first ou abort.",
+		invalidLength:   "Erreur. La longueur du résumé doit être de 0 ou plus.",
+		openInBrowser:   "Ouvrir dans votre navigateur : %s",
+		invalidNumber:   "Erreur. Vous devez saisir un numéro valide.",
+		noTopic:         "Erreur. Vous devez saisir un sujet à rechercher.",
+		noChoiceInTime:  "Erreur. Aucun numéro d'article n'a été saisi à temps.",
+		savedTo:         "Enregistré dans %s",
+		tuiUnsupported:  "La TUI n'est pas prise en charge sous Windows, l'invite est utilisée à la place.",
+		tuiNoTerminal:   "la TUI nécessite un terminal interactif",
+		tuiNoStty:       "la TUI nécessite un terminal prenant en charge stty",
+		tuiSearch:       "Recherche :",
+		tuiSearching:    "Recherche en cours...",
+		tuiLoading:      "Chargement...",
+		tuiResults:      "%s résultats",
+		tuiHelp:         "Entrée : rechercher/lire  Tab : changer de zone  Haut/Bas : sélectionner  Échap : quitter",
+	},
+}
+
+// lookupUILanguage returns the messages of the given -lang-ui language.
+func lookupUILanguage(lang string) (uiMessages, error) {
+	messages, ok := uiLanguages[strings.ToLower(lang)]
+
+	if !ok {
+		langs := make([]string, 0, len(uiLanguages))
+
+		for lang := range uiLanguages {
+			langs = append(langs, lang)
+		}
+
+		sort.Strings(langs)
+
+		return uiMessages{}, fmt.Errorf("unsupported interface language %q, must be one of %s", lang, strings.Join(langs, ", "))
+	}
+
+	return messages, nil
+}
+
+// libraryMessages returns the messages in the form the library's functions take.
+func (m uiMessages) libraryMessages() dwiki.Messages {
+	return dwiki.Messages{
+		NoResults:     m.noResults,
+		SearchResults: m.searchResults,
+		ChoicePrompt:  m.choicePrompt,
+	}
+}
+
+// errorText formats the error for the user.
+func (m uiMessages) errorText(err error) string {
+	return fmt.Sprintf(m.errorFormat, err)
+}
+
+// isYes reports whether the answer to a yes/no prompt is yes.
+func (m uiMessages) isYes(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	for _, yes := range m.yes {
+		if answer == yes {
+			return true
+		}
+	}
+
+	return false
+}
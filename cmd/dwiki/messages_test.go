@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUILanguagesComplete(t *testing.T) {
+	english := reflect.ValueOf(uiLanguages["en"])
+
+	for lang, messages := range uiLanguages {
+		value := reflect.ValueOf(messages)
+
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			name := value.Type().Field(i).Name
+
+			if field.Len() == 0 {
+				t.Errorf("uiLanguages[%q].%s is empty", lang, name)
+				continue
+			}
+
+			// Formatted messages must take the same arguments in every language
+			if field.Kind() == reflect.String {
+				if got, want := strings.Count(field.String(), "%s"), strings.Count(english.Field(i).String(), "%s"); got != want {
+					t.Errorf("uiLanguages[%q].%s has %d %%s verbs, want %d as in English", lang, name, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// groupSeparators maps locale languages to the separator they group thousands with. Languages not listed
+// use a comma, as in English.
+var groupSeparators = map[string]string{
+	"da": ".", "de": ".", "es": ".", "id": ".", "it": ".", "nl": ".", "pt": ".", "tr": ".",
+	"cs": " ", "fi": " ", "fr": " ", "nb": " ", "pl": " ", "ru": " ",
+	"sk": " ", "sv": " ", "uk": " ",
+}
+
+// groupSeparator returns the thousands separator of the user's locale, read from the LC_ALL, LC_NUMERIC and
+// LANG environment variables in that order, e.g. "." for "de_DE.UTF-8".
+func groupSeparator() string {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		locale := os.Getenv(name)
+
+		if locale == "" {
+			continue
+		}
+
+		// Only the language matters, e.g. "de" in "de_DE.UTF-8"
+		lang, _, _ := strings.Cut(locale, "_")
+		lang, _, _ = strings.Cut(lang, ".")
+
+		if separator, ok := groupSeparators[strings.ToLower(lang)]; ok {
+			return separator
+		}
+
+		return ","
+	}
+
+	return ","
+}
+
+// formatCount formats n with its digits grouped in thousands using the locale's separator, e.g. "1,234,567".
+func formatCount(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	separator := groupSeparator()
+
+	var sb strings.Builder
+
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteString(separator)
+		}
+
+		sb.WriteRune(digit)
+	}
+
+	return sign + sb.String()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmars8047/dwiki/pkg/dwiki"
+)
+
+// readLine reads a line from the reader, giving up after the timeout when it is positive. It reports whether a
+// line, possibly empty, was read in time. On a timeout the read is left running in the background, so the reader
+// must not be used again.
+func readLine(reader *bufio.Reader, timeout time.Duration) (string, bool) {
+	if timeout <= 0 {
+		line, _ := reader.ReadString('\n')
+		return line, true
+	}
+
+	lines := make(chan string, 1)
+
+	go func() {
+		line, _ := reader.ReadString('\n')
+		lines <- line
+	}()
+
+	select {
+	case line := <-lines:
+		return line, true
+	case <-time.After(timeout):
+		return "", false
+	}
+}
+
+// matchTitle resolves a choice that isn't a number to the number of the only option whose title contains it,
+// ignoring case. The number is zero when the choice is a number, is empty or matches no option, and also when it
+// matches several, which is then reported as ambiguous.
+func matchTitle(choice string, options []dwiki.Article) (int, bool) {
+	choice = strings.ToLower(strings.TrimSpace(choice))
+
+	if _, err := strconv.Atoi(choice); err == nil || choice == "" {
+		return 0, false
+	}
+
+	number := 0
+
+	for i, option := range options {
+		if !strings.Contains(strings.ToLower(option.Title), choice) {
+			continue
+		}
+
+		if number > 0 {
+			return 0, true
+		}
+
+		number = i + 1
+	}
+
+	return number, false
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dmars8047/dwiki/pkg/dwiki"
+)
+
+// slugify turns a title into a lowercase file name of letters, digits and hyphens, e.g. "Go (programming
+// language)" into "go-programming-language".
+func slugify(title string) string {
+	var slug strings.Builder
+
+	hyphen := false
+
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			slug.WriteRune(r)
+			hyphen = false
+			continue
+		}
+
+		if !hyphen && slug.Len() > 0 {
+			slug.WriteByte('-')
+			hyphen = true
+		}
+	}
+
+	name := strings.TrimSuffix(slug.String(), "-")
+
+	if name == "" {
+		return "article"
+	}
+
+	return name
+}
+
+// markdownNote renders the article, read from the given Wikimedia project, as a markdown note with YAML
+// frontmatter giving its title, url, the date it was saved and its source, the project. An empty project is
+// dwiki.DefaultProject.
+func markdownNote(article *dwiki.Article, project string, saved time.Time) string {
+	if project == "" {
+		project = dwiki.DefaultProject
+	}
+
+	project = strings.ToLower(project)
+
+	var note strings.Builder
+
+	// Quoted strings keep titles containing colons or quotes valid YAML
+	note.WriteString("---\n")
+	fmt.Fprintf(&note, "title: %s\n", strconv.Quote(article.Title))
+	fmt.Fprintf(&note, "url: %s\n", strconv.Quote(article.URL))
+	fmt.Fprintf(&note, "date: %s\n", saved.Format("2006-01-02"))
+	fmt.Fprintf(&note, "source: %s\n", project)
+	note.WriteString("---\n\n")
+
+	fmt.Fprintf(&note, "# %s\n\n", article.Title)
+	fmt.Fprintf(&note, "%s\n\n", article.Preview)
+	// Project names are written capitalized, e.g. "Wiktionary"
+	fmt.Fprintf(&note, "[Read more on %s](%s)\n", strings.ToUpper(project[:1])+project[1:], article.URL)
+
+	return note.String()
+}
+
+// saveNote writes the article, read from the given project, as a markdown note named after its title into the
+// given directory, creating the directory if needed, and returns the path of the file written.
+func saveNote(dir, project string, article *dwiki.Article) (string, error) {
+	err := os.MkdirAll(dir, 0o755)
+
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, slugify(article.Title)+".md")
+
+	err = os.WriteFile(path, []byte(markdownNote(article, project, time.Now())), 0o644)
+
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
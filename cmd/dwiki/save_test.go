@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmars8047/dwiki/pkg/dwiki"
+)
+
+func TestMarkdownNoteSource(t *testing.T) {
+	tests := []struct {
+		project    string
+		wantSource string
+		wantLink   string
+	}{
+		{"", "source: wikipedia\n", "[Read more on Wikipedia]("},
+		{"wiktionary", "source: wiktionary\n", "[Read more on Wiktionary]("},
+		{"Wikiquote", "source: wikiquote\n", "[Read more on Wikiquote]("},
+	}
+
+	article := &dwiki.Article{Title: "Serendipity", URL: "https://en.wiktionary.org/wiki/serendipity", Preview: "A happy accident."}
+
+	for _, test := range tests {
+		t.Run(test.project, func(t *testing.T) {
+			note := markdownNote(article, test.project, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))
+
+			if !strings.Contains(note, test.wantSource) || !strings.Contains(note, test.wantLink) {
+				t.Errorf("markdownNote() = %q, want it to contain %q and %q", note, test.wantSource, test.wantLink)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dmars8047/dwiki/pkg/dwiki"
+)
+
+const (
+	focusSearch = iota
+	focusResults
+)
+
+// tui is a simple full-screen terminal interface with a search box, a result list and a summary pane.
+// It drives the terminal directly with ANSI escape codes and stty so that the CLI keeps no dependencies, which
+// limits it to Unix-like systems. Elsewhere the CLI falls back to the plain prompt.
+type tui struct {
+	ctx    context.Context
+	client *dwiki.Client
+	ui     uiMessages
+
+	query    []rune
+	results  []dwiki.Article
+	selected int
+	focus    int
+	summary  string
+	status   string
+
+	width  int
+	height int
+}
+
+// runTUI runs the terminal interface until the user quits with Esc or Ctrl-C.
+func runTUI(ctx context.Context, client *dwiki.Client, ui uiMessages, topic string) error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return errors.New(ui.tuiNoTerminal)
+	}
+
+	state, err := stty("-g")
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", ui.tuiNoStty, err)
+	}
+
+	_, err = stty("raw", "-echo")
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		stty(state)
+		// Leave the alternate screen and show the cursor again
+		fmt.Print("\x1b[?1049l\x1b[?25h")
+	}()
+
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+
+	t := &tui{ctx: ctx, client: client, ui: ui, query: []rune(topic), width: 80, height: 24}
+
+	t.resize()
+
+	if topic != "" {
+		t.search()
+	}
+
+	buf := make([]byte, 16)
+
+	for {
+		t.draw()
+
+		n, err := os.Stdin.Read(buf)
+
+		if err != nil {
+			return err
+		}
+
+		if n == 0 {
+			continue
+		}
+
+		if !t.handleKey(buf[:n]) {
+			return nil
+		}
+	}
+}
+
+// stty runs stty against the terminal on stdin and returns its trimmed output.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+
+	out, err := cmd.Output()
+
+	return strings.TrimSpace(string(out)), err
+}
+
+// resize reads the terminal size, keeping the previous size if it can't be determined.
+func (t *tui) resize() {
+	size, err := stty("size")
+
+	if err != nil {
+		return
+	}
+
+	var height, width int
+
+	if _, err := fmt.Sscanf(size, "%d %d", &height, &width); err == nil && height > 0 && width > 0 {
+		t.height = height
+		t.width = width
+	}
+}
+
+// handleKey applies a key press and reports whether the interface should keep running.
+func (t *tui) handleKey(key []byte) bool {
+	switch {
+	case len(key) == 0:
+		// Nothing was read
+	case len(key) == 1 && (key[0] == 3 || key[0] == 27):
+		// Ctrl-C or a lone Esc quits
+		return false
+	case string(key) == "\t":
+		if t.focus == focusSearch && len(t.results) > 0 {
+			t.focus = focusResults
+		} else {
+			t.focus = focusSearch
+		}
+	case string(key) == "\x1b[A":
+		if t.focus == focusResults && t.selected > 0 {
+			t.selected--
+		}
+	case string(key) == "\x1b[B":
+		if t.focus == focusResults && t.selected < len(t.results)-1 {
+			t.selected++
+		}
+	case string(key) == "\r" || string(key) == "\n":
+		if t.focus == focusSearch {
+			t.search()
+		} else {
+			t.showSummary()
+		}
+	case len(key) == 1 && (key[0] == 127 || key[0] == 8):
+		if t.focus == focusSearch && len(t.query) > 0 {
+			t.query = t.query[:len(t.query)-1]
+		}
+	case key[0] >= 32 && key[0] != 127:
+		if t.focus == focusSearch {
+			t.query = append(t.query, []rune(string(key))...)
+		}
+	}
+
+	return true
+}
+
+// search runs the query in the search box and moves the focus to the results.
+func (t *tui) search() {
+	query := strings.TrimSpace(string(t.query))
+
+	if query == "" {
+		return
+	}
+
+	t.status = t.ui.tuiSearching
+	t.draw()
+
+	results, err := t.client.Search(t.ctx, query)
+
+	t.summary = ""
+
+	if err != nil {
+		t.status = t.ui.errorText(err)
+		return
+	}
+
+	t.results = results
+	t.selected = 0
+	t.status = fmt.Sprintf(t.ui.tuiResults, formatCount(len(results)))
+
+	if len(results) > 0 {
+		t.focus = focusResults
+	}
+}
+
+// showSummary fetches the summary of the selected result into the summary pane.
+func (t *tui) showSummary() {
+	if t.selected >= len(t.results) {
+		return
+	}
+
+	t.status = t.ui.tuiLoading
+	t.draw()
+
+	article, err := t.client.GetArticleDetails(t.ctx, t.results[t.selected].PageID)
+
+	if err != nil {
+		t.status = t.ui.errorText(err)
+		return
+	}
+
+	t.summary = article.Preview + "\n\n" + t.ui.moreLink + ": " + article.URL
+	t.status = article.Title
+}
+
+// draw redraws the whole screen.
+func (t *tui) draw() {
+	var sb strings.Builder
+
+	sb.WriteString("\x1b[2J\x1b[H")
+
+	searchMarker := " "
+
+	if t.focus == focusSearch {
+		searchMarker = ">"
+	}
+
+	fmt.Fprintf(&sb, "%s %s %s\r\n", searchMarker, t.ui.tuiSearch, string(t.query))
+	sb.WriteString(strings.Repeat("-", t.width) + "\r\n")
+
+	lines := 2
+
+	// Clip the list to the rows left beside the summary, scrolling it to keep the selection in view
+	rows := t.height - 5
+
+	if t.summary != "" {
+		rows /= 2
+	}
+
+	rows = max(rows, 1)
+	first := max(t.selected-rows+1, 0)
+
+	for i, result := range t.results {
+		if i < first || i >= first+rows {
+			continue
+		}
+
+		marker := "  "
+
+		if i == t.selected && t.focus == focusResults {
+			marker = "> "
+		} else if i == t.selected {
+			marker = "* "
+		}
+
+		fmt.Fprintf(&sb, "%s%d. %s\r\n", marker, i+1, result.Title)
+		lines++
+	}
+
+	sb.WriteString(strings.Repeat("-", t.width) + "\r\n")
+	lines++
+
+	// Leave room for the status and help lines at the bottom
+	for _, line := range wrap(t.summary, t.width) {
+		if lines >= t.height-2 {
+			break
+		}
+
+		sb.WriteString(line + "\r\n")
+		lines++
+	}
+
+	fmt.Fprintf(&sb, "\x1b[%d;1H%s\r\n", t.height-1, t.status)
+	sb.WriteString(t.ui.tuiHelp)
+
+	fmt.Print(sb.String())
+}
+
+// wrap breaks text into lines no longer than width, splitting at spaces.
+func wrap(text string, width int) []string {
+	lines := make([]string, 0)
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && len([]rune(line))+1+len([]rune(word)) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+
+			if line == "" {
+				line = word
+			} else {
+				line += " " + word
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,82 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Backend selects the Wikipedia API a Client reads summaries from.
+type Backend string
+
+const (
+	// ActionBackend reads from the action API, api.php, which every wiki has.
+	ActionBackend Backend = "action"
+
+	// RESTBackend reads from the REST API, whose page summaries are cleaner and are served from a cache.
+	RESTBackend Backend = "rest"
+
+	// AutoBackend reads from the REST API and falls back to the action API when the REST request fails.
+	AutoBackend Backend = "auto"
+)
+
+// getTitleSummary returns the article with the given title, with its extract and preview, from the client's
+// Backend. The REST API always follows redirects, so with KeepRedirects set the action API is used instead.
+func (c *Client) getTitleSummary(ctx context.Context, title string) (*Article, error) {
+	backend := c.Backend
+
+	if c.KeepRedirects && (backend == RESTBackend || backend == AutoBackend) {
+		backend = ActionBackend
+	}
+
+	switch backend {
+	case "", ActionBackend:
+		return c.getActionSummary(ctx, title)
+	case RESTBackend:
+		return c.getRESTSummary(ctx, title)
+	case AutoBackend:
+		article, err := c.getRESTSummary(ctx, title)
+
+		// A missing article is missing from both, and a cancelled request shouldn't be retried
+		if err == nil || errors.Is(err, ErrPageNotFound) || ctx.Err() != nil {
+			return article, err
+		}
+
+		return c.getActionSummary(ctx, title)
+	default:
+		return nil, fmt.Errorf("unknown backend %q", c.Backend)
+	}
+}
+
+// getActionSummary returns the article with the given title from the action API.
+func (c *Client) getActionSummary(ctx context.Context, title string) (*Article, error) {
+	page, err := c.getExtractByTitle(ctx, title)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.extractArticle(page), nil
+}
+
+// getRESTSummary returns the article with the given title from the REST /page/summary endpoint. Its extract is
+// the first paragraph of the intro.
+func (c *Client) getRESTSummary(ctx context.Context, title string) (*Article, error) {
+	var summary feedSummary
+
+	err := c.getRESTJSON(ctx, "/page/summary/"+restTitle(c.normalizeTitle(title)), &summary)
+
+	if err != nil {
+		return nil, err
+	}
+
+	article := c.feedArticle(summary)
+
+	if article.Extract == "" {
+		return nil, ErrNoExtract
+	}
+
+	article.Preview = c.preview(article.Extract, false)
+
+	return &article, nil
+}
@@ -0,0 +1,36 @@
+package dwiki
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKeepRedirectsUsesActionBackend(t *testing.T) {
+	for _, backend := range []Backend{ActionBackend, RESTBackend, AutoBackend} {
+		t.Run(string(backend), func(t *testing.T) {
+			var recorder queryRecorder
+
+			// REST requests carry no query, so they match no route and fail the test
+			client := newTestClient(t, recorder.wrap(serveFixtures(t, [][2]string{
+				{"action=query", "extracts_entities.json"},
+			})))
+
+			client.Backend = backend
+			client.KeepRedirects = true
+
+			article, err := client.getTitleSummary(context.Background(), "AT&T 'Death Star'")
+
+			if err != nil {
+				t.Fatalf("getTitleSummary() error = %v", err)
+			}
+
+			if article.PageID != 17555269 {
+				t.Errorf("getTitleSummary() page id = %d, want 17555269", article.PageID)
+			}
+
+			if query := recorder.last(t); query.Has("redirects") {
+				t.Errorf("getTitleSummary() sent redirects=%q, want no redirects parameter", query.Get("redirects"))
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package dwiki
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// maxIntroExtracts is the most intro extracts the API returns in a single request.
+const maxIntroExtracts = 20
+
+// GetSummariesByTitles returns summaries of the articles with the given titles using DefaultClient.
+func GetSummariesByTitles(titles []string) (map[string]string, error) {
+	return DefaultClient.GetSummariesByTitles(context.Background(), titles)
+}
+
+// GetSummariesByTitles returns summaries of the articles with the given titles, following redirects unless
+// the client's KeepRedirects is set. The result is keyed by the titles as given, even when the API normalized or
+// redirected them. Titles without an article or extract are omitted. The titles are fetched in as few requests
+// as the API allows.
+func (c *Client) GetSummariesByTitles(ctx context.Context, titles []string) (map[string]string, error) {
+	summaries := make(map[string]string)
+
+	for start := 0; start < len(titles); start += maxIntroExtracts {
+		end := min(start+maxIntroExtracts, len(titles))
+
+		batch := titles[start:end]
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "extracts"
+		params["exlimit"] = "max"
+		params["explaintext"] = ""
+		params["exintro"] = ""
+		cut := c.limitExtract(params)
+		c.followRedirects(params)
+		params["format"] = "json"
+		params["titles"] = strings.Join(batch, "|")
+
+		extractResponse, err := c.getExtracts(ctx, params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Map every title the API reported back to the one it was resolved from
+		resolved := make(map[string]string)
+
+		for _, normalized := range extractResponse.Query.Normalized {
+			resolved[normalized.From] = normalized.To
+		}
+
+		for _, redirect := range extractResponse.Query.Redirects {
+			resolved[redirect.From] = redirect.To
+		}
+
+		extracts := make(map[string]string)
+
+		for _, page := range extractResponse.Query.Pages {
+			if page.Extract != "" {
+				extracts[page.Title] = page.Extract
+			}
+		}
+
+		for _, title := range batch {
+			// Follow the normalization and then the redirect, if any
+			final := title
+
+			for i := 0; i < 2; i++ {
+				if to, ok := resolved[final]; ok {
+					final = to
+				}
+			}
+
+			if extract, ok := extracts[final]; ok {
+				extract = c.cleanIntro(extract)
+				summaries[title] = c.preview(extract, cut)
+			}
+		}
+	}
+
+	return summaries, nil
+}
+
+// GetSummariesByIDs returns summaries of the articles with the given page ids using DefaultClient.
+func GetSummariesByIDs(pageIds []int) (map[int]string, []int, error) {
+	return DefaultClient.GetSummariesByIDs(context.Background(), pageIds)
+}
+
+// GetSummariesByIDs returns summaries of the articles with the given page ids, keyed by page id, along with the
+// requested ids that the API returned no extract for, e.g. because the page was deleted, in the order they
+// were requested. The ids are fetched in as few requests as the API allows.
+func (c *Client) GetSummariesByIDs(ctx context.Context, pageIds []int) (map[int]string, []int, error) {
+	summaries := make(map[int]string)
+	missing := make([]int, 0)
+
+	for start := 0; start < len(pageIds); start += maxIntroExtracts {
+		end := min(start+maxIntroExtracts, len(pageIds))
+
+		batch := pageIds[start:end]
+
+		ids := make([]string, 0, len(batch))
+
+		for _, pageId := range batch {
+			ids = append(ids, strconv.Itoa(pageId))
+		}
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "extracts"
+		params["exlimit"] = "max"
+		params["explaintext"] = ""
+		params["exintro"] = ""
+		cut := c.limitExtract(params)
+		params["format"] = "json"
+		params["pageids"] = strings.Join(ids, "|")
+
+		extractResponse, err := c.getExtracts(ctx, params)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		extracts := make(map[int]string)
+
+		for _, page := range extractResponse.Query.Pages {
+			if !page.Missing && page.Extract != "" {
+				extracts[page.Pageid] = page.Extract
+			}
+		}
+
+		// Check every requested id against the response rather than trusting it to return them all
+		for _, pageId := range batch {
+			extract, ok := extracts[pageId]
+
+			if !ok {
+				missing = append(missing, pageId)
+				continue
+			}
+
+			extract = c.cleanIntro(extract)
+			summaries[pageId] = c.preview(extract, cut)
+		}
+	}
+
+	return summaries, missing, nil
+}
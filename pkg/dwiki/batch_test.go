@@ -0,0 +1,42 @@
+package dwiki
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetSummariesByIDsReportsMissingIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+	}{
+		{"id left out of the response", "extracts_batch_dropped.json"},
+		{"id marked missing", "extracts_batch_missing.json"},
+	}
+
+	want := map[int]string{
+		736:      "Albert Einstein was a German-born theoretical physicist.",
+		25039021: "Go is a high-level general-purpose programming language.",
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveFixture(t, test.fixture))
+
+			summaries, missing, err := client.GetSummariesByIDs(context.Background(), []int{736, 999999999, 25039021})
+
+			if err != nil {
+				t.Fatalf("GetSummariesByIDs() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(summaries, want) {
+				t.Errorf("summaries = %v, want %v", summaries, want)
+			}
+
+			if !reflect.DeepEqual(missing, []int{999999999}) {
+				t.Errorf("missing = %v, want [999999999]", missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package dwiki
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API responses keyed by their request url. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// uncached returns a copy of the client that bypasses its Cache, for requests whose response differs from one
+// call to the next, such as a random article or today's featured feed, which a cache would freeze. The copy shares
+// the client's state.
+func (c *Client) uncached() *Client {
+	c.state()
+
+	clone := *c
+	clone.Cache = nil
+	return &clone
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+type memoryCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]memoryCacheEntry
+}
+
+// NewMemoryCache returns a Cache that keeps responses in memory for the given time to live.
+// A non-positive ttl keeps them until the process exits.
+func NewMemoryCache(ttl time.Duration) Cache {
+	return &memoryCache{ttl: ttl, entries: make(map[string]memoryCacheEntry)}
+}
+
+func (m *memoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (m *memoryCache) Set(key string, value []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := memoryCacheEntry{value: value}
+
+	if m.ttl > 0 {
+		entry.expires = time.Now().Add(m.ttl)
+	}
+
+	m.entries[key] = entry
+}
+
+type diskCacheEntry struct {
+	Key    string    `json:"key"`
+	Stored time.Time `json:"stored"`
+	Value  []byte    `json:"value"`
+}
+
+type diskCache struct {
+	dir string
+	ttl time.Duration
+
+	// prune removes the expired entries of the directory, once per process, with the first response stored.
+	prune sync.Once
+}
+
+// NewDiskCache returns a Cache that keeps responses as JSON files in the given directory, created when the first
+// response is stored, so that they outlive the process. Responses older than ttl are ignored; a non-positive
+// ttl keeps them until the files are removed. Expired files are deleted when they are read, and the whole
+// directory is swept of them when the first response is stored; the sweep only touches files named the way the
+// cache names its own, so other files in the directory are left alone. Files that can't be read or parsed are
+// treated as misses, and responses that can't be written are simply not cached.
+func NewDiskCache(dir string, ttl time.Duration) Cache {
+	return &diskCache{dir: dir, ttl: ttl}
+}
+
+// path returns the file holding the entry for the key, named after a hash of the key.
+func (d *diskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *diskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(d.path(key))
+
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskCacheEntry
+
+	// The key is compared as well so that a hash collision is a miss rather than a wrong response
+	if json.Unmarshal(data, &entry) != nil || entry.Key != key {
+		return nil, false
+	}
+
+	if d.ttl > 0 && time.Since(entry.Stored) > d.ttl {
+		os.Remove(d.path(key))
+		return nil, false
+	}
+
+	return entry.Value, true
+}
+
+func (d *diskCache) Set(key string, value []byte) {
+	data, err := json.Marshal(diskCacheEntry{Key: key, Stored: time.Now(), Value: value})
+
+	if err != nil {
+		return
+	}
+
+	if os.MkdirAll(d.dir, 0o755) != nil {
+		return
+	}
+
+	d.prune.Do(d.removeExpired)
+
+	// Write to a temporary file first so that a concurrent Get never reads a partial entry
+	tmp, err := os.CreateTemp(d.dir, "*.tmp")
+
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(data)
+	closeErr := tmp.Close()
+
+	if err != nil || closeErr != nil || os.Rename(tmp.Name(), d.path(key)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// diskCacheFilePattern matches the names of the files a disk cache writes: entries named after the hex sha256 of
+// their key, and the temporary files os.CreateTemp names after the "*.tmp" pattern.
+var diskCacheFilePattern = regexp.MustCompile(`^(?:[0-9a-f]{64}\.json|[0-9]+\.tmp)$`)
+
+// removeExpired deletes the entries older than the cache's ttl, along with temporary files left behind by
+// interrupted writes. Entries are written once, so a file's modification time is when its response was stored.
+// Only files named the way the cache names them are removed, so that other files in the directory are kept.
+func (d *diskCache) removeExpired() {
+	if d.ttl <= 0 {
+		return
+	}
+
+	files, err := os.ReadDir(d.dir)
+
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		name := file.Name()
+
+		if file.IsDir() || !diskCacheFilePattern.MatchString(name) {
+			continue
+		}
+
+		info, err := file.Info()
+
+		if err == nil && time.Since(info.ModTime()) > d.ttl {
+			os.Remove(filepath.Join(d.dir, name))
+		}
+	}
+}
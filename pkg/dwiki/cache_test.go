@@ -0,0 +1,108 @@
+package dwiki
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheRemovesExpiredFiles(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewDiskCache(dir, time.Hour)
+
+	cache.Set("stale", []byte("{}"))
+	cache.Set("fresh", []byte("{}"))
+
+	stale := cache.(*diskCache).path("stale")
+	old := time.Now().Add(-2 * time.Hour)
+
+	// A temporary file named as os.CreateTemp names them, and files the cache didn't write
+	leftover := filepath.Join(dir, "3141592653.tmp")
+	foreign := []string{filepath.Join(dir, "settings.json"), filepath.Join(dir, "draft.tmp")}
+
+	for _, path := range append([]string{leftover}, foreign...) {
+		if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The sweep goes by the files' modification time
+	for _, path := range append([]string{stale, leftover}, foreign...) {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A new cache over the same directory sweeps it with its first stored response
+	NewDiskCache(dir, time.Hour).Set("other", []byte("{}"))
+
+	for _, path := range []string{stale, leftover} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after the sweep", filepath.Base(path))
+		}
+	}
+
+	for _, path := range foreign {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s, not written by the cache, was removed: %v", filepath.Base(path), err)
+		}
+	}
+
+	if _, ok := cache.Get("fresh"); !ok {
+		t.Error("fresh entry was removed")
+	}
+}
+
+func TestDiskCacheRemovesExpiredEntryOnRead(t *testing.T) {
+	dir := t.TempDir()
+
+	NewDiskCache(dir, time.Hour).Set("key", []byte("{}"))
+
+	// A client using a shorter ttl sees the entry as expired
+	cache := NewDiskCache(dir, time.Nanosecond)
+
+	time.Sleep(time.Millisecond)
+
+	if _, ok := cache.Get("key"); ok {
+		t.Fatal("Get() returned an expired entry")
+	}
+
+	if _, err := os.Stat(cache.(*diskCache).path("key")); !os.IsNotExist(err) {
+		t.Error("expired entry file still exists after Get()")
+	}
+}
+
+func TestCacheKeepsVariantsApart(t *testing.T) {
+	// The REST API sends the variant only in the Accept-Language header, so the urls are identical
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"variant": "` + r.Header.Get("Accept-Language") + `"}`))
+	})
+
+	bufferedClient(client)
+
+	for _, variant := range []string{"sr-ec", "sr-el", "sr-ec"} {
+		client.Variant = variant
+
+		var v struct {
+			Variant string `json:"variant"`
+		}
+
+		err := client.getRESTJSON(context.Background(), "/page/summary/Beograd", &v)
+
+		if err != nil {
+			t.Fatalf("getRESTJSON() error = %v", err)
+		}
+
+		if v.Variant != variant {
+			t.Errorf("getRESTJSON() with Variant %q returned the %q response", variant, v.Variant)
+		}
+	}
+
+	if hits := client.Stats().CacheHits; hits != 1 {
+		t.Errorf("CacheHits = %d, want 1", hits)
+	}
+}
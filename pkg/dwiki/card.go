@@ -0,0 +1,52 @@
+package dwiki
+
+import "context"
+
+// Card is the summary of an article served by the REST API, with everything needed to show it as a card.
+type Card struct {
+	Title       string `json:"title"`
+	PageID      int    `json:"pageid"`
+	Description string `json:"description,omitempty"`
+	Extract     string `json:"extract"`
+
+	// Thumbnail is the url of the thumbnail of the article's lead image, or empty if it has none.
+	Thumbnail string `json:"thumbnail,omitempty"`
+
+	DesktopURL string `json:"desktopurl"`
+	MobileURL  string `json:"mobileurl"`
+}
+
+// GetRESTCard returns the summary card of the article with the given title using DefaultClient.
+func GetRESTCard(title string) (*Card, error) {
+	return DefaultClient.GetRESTCard(context.Background(), title)
+}
+
+// GetRESTCard returns the summary card of the article with the given title, its short description, intro extract,
+// thumbnail and urls, in a single request to the REST /page/summary endpoint. The endpoint follows redirects.
+// It returns ErrPageNotFound when no article has the title.
+func (c *Client) GetRESTCard(ctx context.Context, title string) (*Card, error) {
+	var summary feedSummary
+
+	err := c.getRESTJSON(ctx, "/page/summary/"+restTitle(c.normalizeTitle(title)), &summary)
+
+	if err != nil {
+		return nil, err
+	}
+
+	article := c.feedArticle(summary)
+
+	card := &Card{
+		Title:       article.Title,
+		PageID:      article.PageID,
+		Description: c.decodeText(summary.Description),
+		Extract:     article.Extract,
+		DesktopURL:  article.URL,
+		MobileURL:   summary.ContentURLs.Mobile.Page,
+	}
+
+	if summary.Thumbnail != nil {
+		card.Thumbnail = summary.Thumbnail.Source
+	}
+
+	return card, nil
+}
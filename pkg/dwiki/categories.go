@@ -0,0 +1,369 @@
+package dwiki
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// categoryPrefix is the namespace prefix of category titles.
+const categoryPrefix = "Category:"
+
+type categoriesResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Clcontinue string `json:"clcontinue"`
+		Continue   string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		Pages []struct {
+			Pageid     int    `json:"pageid"`
+			Title      string `json:"title"`
+			Missing    bool   `json:"missing"`
+			Categories []struct {
+				Ns    int    `json:"ns"`
+				Title string `json:"title"`
+			} `json:"categories"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+type categoryMembersResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Cmcontinue string `json:"cmcontinue"`
+		Continue   string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		CategoryMembers []struct {
+			Pageid int    `json:"pageid"`
+			Ns     int    `json:"ns"`
+			Title  string `json:"title"`
+		} `json:"categorymembers"`
+	} `json:"query"`
+}
+
+// CategoryNode is a category in a tree returned by GetCategoryTree. Name has no "Category:" prefix.
+type CategoryNode struct {
+	Name          string          `json:"name"`
+	Subcategories []*CategoryNode `json:"subcategories,omitempty"`
+}
+
+// getCategories returns the visible categories of the article with the given page id, without the
+// "Category:" prefix, following continuation until all have been read.
+func (c *Client) getCategories(ctx context.Context, pageId int) ([]string, error) {
+	categories, err := c.getCategoriesOf(ctx, []int{pageId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(make([]string, 0), categories[pageId]...), nil
+}
+
+// getCategoriesOf returns the visible categories of each of the given articles, keyed by page id, without the
+// "Category:" prefix. The articles are fetched in as few requests as the API allows, following continuation
+// until all categories have been read.
+func (c *Client) getCategoriesOf(ctx context.Context, pageIds []int) (map[int][]string, error) {
+	categories := make(map[int][]string)
+
+	for start := 0; start < len(pageIds); start += maxPageIds {
+		end := min(start+maxPageIds, len(pageIds))
+
+		ids := make([]string, 0, end-start)
+
+		for _, pageId := range pageIds[start:end] {
+			ids = append(ids, strconv.Itoa(pageId))
+		}
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "categories"
+		params["clshow"] = "!hidden"
+		params["cllimit"] = "max"
+		params["format"] = "json"
+		params["pageids"] = strings.Join(ids, "|")
+
+		for {
+			var categoriesResponse categoriesResponse
+
+			err := c.getJSON(ctx, params, &categoriesResponse)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, page := range categoriesResponse.Query.Pages {
+				for _, category := range page.Categories {
+					categories[page.Pageid] = append(categories[page.Pageid], strings.TrimPrefix(category.Title, categoryPrefix))
+				}
+			}
+
+			if categoriesResponse.Continue.Clcontinue == "" {
+				break
+			}
+
+			params["clcontinue"] = categoriesResponse.Continue.Clcontinue
+		}
+	}
+
+	return categories, nil
+}
+
+// SharedCategories returns the categories two articles have in common using DefaultClient.
+func SharedCategories(pageIdA, pageIdB int) ([]string, error) {
+	return DefaultClient.SharedCategories(context.Background(), pageIdA, pageIdB)
+}
+
+// SharedCategories returns the visible categories that the articles with the given page ids have in common, in the
+// order they are listed for the first article, in one request. Category names are compared with underscores
+// read as spaces. An article without categories shares none, so the result is then empty.
+func (c *Client) SharedCategories(ctx context.Context, pageIdA, pageIdB int) ([]string, error) {
+	categories, err := c.getCategoriesOf(ctx, []int{pageIdA, pageIdB})
+
+	if err != nil {
+		return nil, err
+	}
+
+	inB := make(map[string]bool)
+
+	for _, category := range categories[pageIdB] {
+		inB[normalizeCategory(category)] = true
+	}
+
+	shared := make([]string, 0)
+
+	for _, category := range categories[pageIdA] {
+		if inB[normalizeCategory(category)] {
+			shared = append(shared, category)
+		}
+	}
+
+	return shared, nil
+}
+
+// normalizeCategory returns the form of a category name used for comparisons.
+func normalizeCategory(category string) string {
+	return strings.TrimSpace(strings.ReplaceAll(category, "_", " "))
+}
+
+// GetCategoryTree returns the subcategories of the given category down to the given depth using DefaultClient.
+func GetCategoryTree(category string, depth int) (*CategoryNode, error) {
+	return DefaultClient.GetCategoryTree(context.Background(), category, depth)
+}
+
+// GetCategoryTree returns the given category, with or without its "Category:" prefix, and its subcategories
+// down to the given depth; a depth of 0 returns the category alone. Categories can contain each other, so a
+// category already in the tree is listed again without its subcategories rather than expanded a second time.
+func (c *Client) GetCategoryTree(ctx context.Context, category string, depth int) (*CategoryNode, error) {
+	root := &CategoryNode{Name: strings.TrimPrefix(category, categoryPrefix)}
+
+	visited := map[string]bool{root.Name: true}
+
+	err := c.expandCategory(ctx, root, depth, visited)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// expandCategory fills in the subcategories of the node down to the given depth, skipping visited categories.
+func (c *Client) expandCategory(ctx context.Context, node *CategoryNode, depth int, visited map[string]bool) error {
+	if depth <= 0 {
+		return nil
+	}
+
+	subcategories, err := c.getSubcategories(ctx, node.Name)
+
+	if err != nil {
+		return err
+	}
+
+	for _, name := range subcategories {
+		child := &CategoryNode{Name: name}
+		node.Subcategories = append(node.Subcategories, child)
+
+		if visited[name] {
+			continue
+		}
+
+		visited[name] = true
+
+		err = c.expandCategory(ctx, child, depth-1, visited)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSubcategories returns the names of the direct subcategories of the given category, without the
+// "Category:" prefix, following continuation until all have been read.
+func (c *Client) getSubcategories(ctx context.Context, category string) ([]string, error) {
+	subcategories := make([]string, 0)
+
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["list"] = "categorymembers"
+	params["cmtitle"] = categoryPrefix + category
+	params["cmtype"] = "subcat"
+	params["cmlimit"] = "max"
+	params["format"] = "json"
+
+	for {
+		var categoryMembersResponse categoryMembersResponse
+
+		err := c.getJSON(ctx, params, &categoryMembersResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range categoryMembersResponse.Query.CategoryMembers {
+			subcategories = append(subcategories, strings.TrimPrefix(member.Title, categoryPrefix))
+		}
+
+		if categoryMembersResponse.Continue.Cmcontinue == "" {
+			break
+		}
+
+		params["cmcontinue"] = categoryMembersResponse.Continue.Cmcontinue
+	}
+
+	return subcategories, nil
+}
+
+// GetCategoryMembersPage returns one page of the articles in the given category using DefaultClient.
+func GetCategoryMembersPage(category, cursor string, limit int) ([]Article, string, error) {
+	return DefaultClient.GetCategoryMembersPage(context.Background(), category, cursor, limit)
+}
+
+// GetCategoryMembersPage returns up to limit articles in the given category, with or without its "Category:"
+// prefix, starting at the given cursor; pass an empty cursor for the first page. The returned cursor fetches
+// the next page and is empty after the last one. Cursors are opaque and only valid for the same category.
+// A limit of 0 or less returns as many articles as the API allows. Subcategories and files are not included.
+func (c *Client) GetCategoryMembersPage(
+	ctx context.Context, category, cursor string, limit int,
+) ([]Article, string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["list"] = "categorymembers"
+	params["cmtitle"] = categoryPrefix + strings.TrimPrefix(category, categoryPrefix)
+	params["cmtype"] = "page"
+	params["cmlimit"] = "max"
+	params["format"] = "json"
+
+	if limit > 0 {
+		params["cmlimit"] = strconv.Itoa(limit)
+	}
+
+	if cursor != "" {
+		params["cmcontinue"] = cursor
+	}
+
+	var categoryMembersResponse categoryMembersResponse
+
+	err := c.getJSON(ctx, params, &categoryMembersResponse)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	members := make([]Article, 0, len(categoryMembersResponse.Query.CategoryMembers))
+
+	for _, member := range categoryMembersResponse.Query.CategoryMembers {
+		members = append(members, Article{
+			Title:  c.decodeText(member.Title),
+			PageID: member.Pageid,
+		})
+	}
+
+	return members, categoryMembersResponse.Continue.Cmcontinue, nil
+}
+
+// OtherCategory is the group SearchGrouped puts results without a visible category in.
+const OtherCategory = "Other"
+
+// SearchGrouped searches for the topic and groups the results by category using DefaultClient.
+func SearchGrouped(topic string, opts ...Option) (map[string][]Article, error) {
+	return DefaultClient.SearchGrouped(context.Background(), topic, opts...)
+}
+
+// SearchGrouped searches for the topic like Search and groups the results by their primary category, keeping rank
+// order within each group. A result's primary category is the one of its visible categories shared by the most
+// results, so that related results end up together; ties go to the category listed first. Results without a
+// visible category are grouped under OtherCategory. Each result's Categories are filled in, at the cost of one
+// extra request per 50 results, and more when their categories don't fit in one response and are continued.
+func (c *Client) SearchGrouped(ctx context.Context, topic string, opts ...Option) (map[string][]Article, error) {
+	articles, err := c.Search(ctx, topic, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageIds := make([]int, 0, len(articles))
+
+	for _, article := range articles {
+		pageIds = append(pageIds, article.PageID)
+	}
+
+	categories, err := c.getCategoriesOf(ctx, pageIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+
+	for i := range articles {
+		articles[i].Categories = categories[articles[i].PageID]
+
+		for _, category := range articles[i].Categories {
+			counts[category]++
+		}
+	}
+
+	groups := make(map[string][]Article)
+
+	for _, article := range articles {
+		primary := OtherCategory
+		best := 0
+
+		for _, category := range article.Categories {
+			if counts[category] > best {
+				primary = category
+				best = counts[category]
+			}
+		}
+
+		groups[primary] = append(groups[primary], article)
+	}
+
+	return groups, nil
+}
+
+// SearchInCategory searches for articles matching the topic within the given category using DefaultClient.
+func SearchInCategory(topic, category string, limit int) ([]Article, error) {
+	return DefaultClient.SearchInCategory(context.Background(), topic, category, limit)
+}
+
+// SearchInCategory searches for up to limit articles matching the topic that are directly in the given category,
+// with or without its "Category:" prefix, e.g. "Computer science". The category is added to the search as the
+// incategory: operator, quoted so that multi-word names work: `incategory:"Computer science"`. Articles in
+// subcategories don't match; add the deepcat: operator to the topic for those where the wiki supports it.
+func (c *Client) SearchInCategory(ctx context.Context, topic, category string, limit int) ([]Article, error) {
+	name := strings.ReplaceAll(strings.TrimPrefix(category, categoryPrefix), `"`, "")
+
+	return c.Search(ctx, topic+` incategory:"`+name+`"`, WithLimit(limit))
+}
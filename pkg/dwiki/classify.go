@@ -0,0 +1,185 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ArticleKind is the kind of page an article is, as reported by ClassifyArticle.
+type ArticleKind string
+
+const (
+	// StandardArticle is a regular article.
+	StandardArticle ArticleKind = "standard"
+
+	// DisambiguationArticle is a page listing the articles a term may refer to.
+	DisambiguationArticle ArticleKind = "disambiguation"
+
+	// RedirectArticle is a page that redirects to another article.
+	RedirectArticle ArticleKind = "redirect"
+)
+
+// Classification describes what kind of page an article is, along with its short description.
+type Classification struct {
+	Kind        ArticleKind `json:"kind"`
+	Description string      `json:"description,omitempty"`
+	WikidataID  string      `json:"wikidataId,omitempty"`
+}
+
+type classifyResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Pageid      int               `json:"pageid"`
+			Title       string            `json:"title"`
+			Missing     bool              `json:"missing"`
+			Redirect    bool              `json:"redirect"`
+			Description string            `json:"description"`
+			PageProps   map[string]string `json:"pageprops"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// ClassifyArticle reports the kind and short description of the page with the given id using DefaultClient.
+func ClassifyArticle(pageId int) (*Classification, error) {
+	return DefaultClient.ClassifyArticle(context.Background(), pageId)
+}
+
+// ClassifyArticle reports whether the page with the given id is a standard article, a disambiguation page or a
+// redirect, along with its short description and Wikidata item id, in a single request. Redirects are not
+// followed, so a redirect is classified as such rather than as its target.
+func (c *Client) ClassifyArticle(ctx context.Context, pageId int) (*Classification, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info|pageprops|description"
+	params["ppprop"] = "disambiguation|wikibase_item"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var classifyResponse classifyResponse
+
+	err := c.getJSON(ctx, params, &classifyResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, page := range classifyResponse.Query.Pages {
+		if page.Pageid != pageId || page.Missing {
+			continue
+		}
+
+		classification := &Classification{
+			Kind:        StandardArticle,
+			Description: c.decodeText(page.Description),
+			WikidataID:  page.PageProps["wikibase_item"],
+		}
+
+		// The disambiguation prop is present, with an empty value, only on disambiguation pages
+		if _, ok := page.PageProps["disambiguation"]; ok {
+			classification.Kind = DisambiguationArticle
+		}
+
+		if page.Redirect {
+			classification.Kind = RedirectArticle
+		}
+
+		return classification, nil
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+}
+
+// ResultStats breaks down the raw results of a search by the kind of page they are, as reported by AnalyzeResults.
+type ResultStats struct {
+	// TotalHits is the number of pages the search engine reports as matching, most of which aren't in Results.
+	TotalHits int `json:"totalHits"`
+
+	// Results is the number of raw results that were classified.
+	Results int `json:"results"`
+
+	Articles        int `json:"articles"`
+	Disambiguations int `json:"disambiguations"`
+
+	// Redirects counts the results that matched through the title of a redirect to them rather than their own.
+	Redirects int `json:"redirects"`
+}
+
+// AnalyzeResults breaks down the raw search results for the topic by kind using DefaultClient.
+func AnalyzeResults(topic string) (*ResultStats, error) {
+	return DefaultClient.AnalyzeResults(context.Background(), topic)
+}
+
+// AnalyzeResults runs the search Search would for the topic, without removing disambiguation pages, and counts
+// how many of the first SearchLimit results are standard articles, disambiguation pages and redirects, along with
+// the total number of hits. The search engine folds a redirect into the article it points to, so a result is
+// counted as a redirect when it matched through a redirect's title. A disambiguation page is counted as such
+// however it matched, and results whose page no longer exists aren't counted as any kind. The results are
+// classified in a single batched request.
+func (c *Client) AnalyzeResults(ctx context.Context, topic string) (*ResultStats, error) {
+	params := searchParams(topic, c.searchLimit())
+
+	params["srprop"] = "redirecttitle"
+
+	var searchResponse searchResponse
+
+	err := c.getJSON(ctx, params, &searchResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ResultStats{
+		TotalHits: searchResponse.Query.Searchinfo.Totalhits,
+		Results:   len(searchResponse.Query.Search),
+	}
+
+	redirected := make(map[int]bool)
+	pageIds := make([]string, 0, len(searchResponse.Query.Search))
+
+	for _, result := range searchResponse.Query.Search {
+		redirected[result.Pageid] = result.RedirectTitle != ""
+		pageIds = append(pageIds, strconv.Itoa(result.Pageid))
+	}
+
+	for start := 0; start < len(pageIds); start += maxPageIds {
+		end := min(start+maxPageIds, len(pageIds))
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "info|pageprops"
+		params["ppprop"] = "disambiguation"
+		params["pageids"] = strings.Join(pageIds[start:end], "|")
+		params["format"] = "json"
+
+		var classifyResponse classifyResponse
+
+		err := c.getJSON(ctx, params, &classifyResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range classifyResponse.Query.Pages {
+			if page.Missing {
+				continue
+			}
+
+			if _, ok := page.PageProps["disambiguation"]; ok {
+				stats.Disambiguations++
+			} else if page.Redirect || redirected[page.Pageid] {
+				stats.Redirects++
+			} else {
+				stats.Articles++
+			}
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,782 @@
+package dwiki
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLanguage is the Wikipedia language edition queried when a Client has no Language set.
+	DefaultLanguage = "en"
+
+	// SimpleEnglish is the language code of the Simple English Wikipedia, simple.wikipedia.org, whose articles are
+	// written in plainer English for learners, e.g. Client{Language: SimpleEnglish, FallbackLanguage: "en"}.
+	SimpleEnglish = "simple"
+
+	// DefaultProject is the Wikimedia project queried when a Client has no Project set.
+	DefaultProject = "wikipedia"
+
+	// DefaultSearchLimit is the number of search results listed when a Client has no SearchLimit set.
+	DefaultSearchLimit = 10
+
+	// DefaultSummaryLength is the character limit of a summary when a Client has no SummaryLength set.
+	DefaultSummaryLength = 1024
+
+	// DefaultMoreLinkLabel is the label of the link that follows a summary when a Client has no MoreLinkLabel set.
+	DefaultMoreLinkLabel = "Find out more"
+
+	// DefaultEllipsis marks a truncated summary when a Client has no Ellipsis set.
+	DefaultEllipsis = "..."
+)
+
+var languagePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// projects lists the Wikimedia projects a Client's Project may name. They all serve the same API under
+// <language>.<project>.org.
+var projects = map[string]bool{
+	"wikipedia":   true,
+	"wiktionary":  true,
+	"wikiquote":   true,
+	"wikinews":    true,
+	"wikibooks":   true,
+	"wikisource":  true,
+	"wikiversity": true,
+	"wikivoyage":  true,
+}
+
+// Client makes requests to the Wikipedia API. The zero value is ready to use and queries the
+// English Wikipedia with the package defaults.
+type Client struct {
+	// HTTPClient is the client used to make requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Language is the Wikipedia language edition to query, e.g. "en" or "fr".
+	// If empty, DefaultLanguage is used.
+	Language string
+
+	// FallbackLanguage is the language edition GetSummaryForTitle looks the title up in when Language has no
+	// article with it, e.g. "en" for a client querying SimpleEnglish, which covers far fewer topics. Lookups by
+	// page id don't fall back, as page ids differ between editions. If empty, there is no fallback.
+	FallbackLanguage string
+
+	// Project is the Wikimedia project to query in Language, e.g. "wiktionary" for definitions or "wikiquote" for
+	// quotes: one of wikipedia, wiktionary, wikiquote, wikinews, wikibooks, wikisource, wikiversity or
+	// wikivoyage. If empty, DefaultProject is used.
+	Project string
+
+	// Endpoints lists the base urls of the wikis to query, e.g. "https://en.wikipedia.org" followed by a mirror.
+	// Requests go to the first endpoint and fail over to the next one on a network error or a 5xx response.
+	// When set, Language and Project no longer select the wiki, but Language should still name the endpoints'
+	// edition: functions that query other editions, such as SearchMultiLang, replace the leading language label of
+	// the endpoints' hosts, e.g. turning https://en.wikipedia.org into https://fr.wikipedia.org, and fail when no
+	// endpoint has one. If empty, the edition of Project for Language is used.
+	Endpoints []string
+
+	// Variant is the script or regional variant to return content in on wikis that convert between them, e.g.
+	// "sr-latn" or "sr-ec" on the Serbian Wikipedia and "zh-hans" or "zh-hant" on the Chinese one. It is sent
+	// as the variant parameter and as the Accept-Language header, and is part of the key responses are cached
+	// under. If empty, the wiki's default is used.
+	Variant string
+
+	// AnonymousOrigin adds origin=* to action API requests, which the API requires to answer anonymous
+	// cross-origin requests, e.g. when going through a browser-facing proxy. It has no effect on server-side use.
+	AnonymousOrigin bool
+
+	// KeepRedirects stops the titles given to functions such as ArticleExists and GetSummariesByTitles from being
+	// resolved through redirects, by leaving out the redirects parameter, so that a redirect is looked up as the
+	// page itself. By default redirects are followed to their targets. See FindRedirectTarget.
+	KeepRedirects bool
+
+	// Backend selects the API summaries by title, such as those of GetSummaryForTitle, are read from:
+	// ActionBackend, RESTBackend or AutoBackend. With AutoBackend the REST API is tried first and a failed
+	// request, other than for a missing article, is retried on the action API. The REST summary holds only the
+	// first paragraph of the intro and is truncated on the client even with ExtractChars. The REST API can't
+	// keep redirects, so with KeepRedirects set the action API is used whatever the Backend. If empty,
+	// ActionBackend is used.
+	Backend Backend
+
+	// SearchLimit is the maximum number of search results listed. If zero, DefaultSearchLimit is used.
+	SearchLimit int
+
+	// SummaryLength is the maximum number of characters in a summary. If zero, DefaultSummaryLength
+	// is used. A negative value disables truncation.
+	SummaryLength int
+
+	// SummaryRatio, if between 0 and 1, limits a summary to that fraction of the full intro extract, e.g. 0.25
+	// for a quarter, instead of to SummaryLength characters. This gives long and short intros previews of
+	// similar proportions.
+	SummaryRatio float64
+
+	// Ellipsis is appended to summaries that were truncated, e.g. "…" or " [read more]". It is not added to
+	// summaries that fit. If empty, DefaultEllipsis is used.
+	Ellipsis string
+
+	// ExtractChars, if positive, has the API cut intro extracts to about that many characters at a word boundary,
+	// using the exchars parameter, instead of truncating summaries on the client. The cut applies to the extracts
+	// themselves, so Article.Extract is shortened too, and SummaryLength and SummaryRatio are ignored for them; the
+	// API's mark of the cut is replaced with Ellipsis. Summaries read from APIs without exchars, such as the REST
+	// summaries of RESTBackend, are still truncated on the client. The API allows at most 1200 characters, and
+	// larger values are lowered to that.
+	ExtractChars int
+
+	// MoreLinkLabel is the label of the link to the article that follows a summary, as in "Find out more: <url>".
+	// If empty, DefaultMoreLinkLabel is used.
+	MoreLinkLabel string
+
+	// Messages overrides the user-facing text written by the interactive functions, e.g. to translate it.
+	// See Messages.
+	Messages Messages
+
+	// InfoboxDigest prepends a one-line digest of the article's infobox to the summaries written by
+	// WriteArticleSummary, e.g. "Born: 1879 · Died: 1955 · Fields: Physics", for biography-style articles. Articles
+	// without a recognizable infobox get the summary alone. It costs one extra request per summary.
+	InfoboxDigest bool
+
+	// OmitMoreLink leaves the link to the article out of summaries, e.g. when the url is shown elsewhere.
+	OmitMoreLink bool
+
+	// RawText keeps titles, snippets and extracts exactly as the API returned them. By default HTML entities
+	// such as "&amp;" are decoded for display.
+	RawText bool
+
+	// LeadOnly cuts intro extracts at their first heading line, for the articles whose intro extract runs past
+	// the lead. Full extracts, such as those of GetStructuredExtract, are left whole. See CutAtFirstHeading.
+	LeadOnly bool
+
+	// StripCitations removes leftover reference markers such as "[1]" from extracts. See StripCitations.
+	StripCitations bool
+
+	// NormalizeParagraphs separates the paragraphs of extracts with exactly one blank line. See
+	// NormalizeParagraphs.
+	NormalizeParagraphs bool
+
+	// StripPronunciation removes the pronunciation parenthetical that often follows the title at the start of
+	// an intro. See StripPronunciation.
+	StripPronunciation bool
+
+	// MaxRetries is the number of times a throttled request is retried, honoring the Retry-After header.
+	// If zero, DefaultMaxRetries is used. A negative value disables retries.
+	MaxRetries int
+
+	// FailOnWarnings makes API warnings, such as those for unrecognized parameters, fail the request with
+	// an APIWarnings error. By default warnings are ignored.
+	FailOnWarnings bool
+
+	// RequestInterval is the minimum time between the start of two requests, shared by every goroutine
+	// using the client. Zero means no rate limiting.
+	RequestInterval time.Duration
+
+	// DryRun builds the request urls without calling the API. Each url is written to Debug and logged to
+	// Logger, when set, and recorded for DryRunURLs. Every response is treated as empty, so most functions
+	// return empty results and a nil error, while functions that need an article, such as GetArticleDetails,
+	// report it as not found. Requests that depend on an earlier response are not built.
+	DryRun bool
+
+	// MaxConcurrency is the maximum number of requests in flight at once, shared by every goroutine using the
+	// client. Requests over the limit wait for a free slot. A throttled request frees its slot while it waits to
+	// be retried. Zero means no limit.
+	MaxConcurrency int
+
+	// MaxResponseBytes is the largest response body read, in bytes. A longer response fails with
+	// ErrResponseTooLarge. Zero means no limit.
+	MaxResponseBytes int64
+
+	// ShareRequests makes concurrent identical requests, such as the same search fired twice while typing, share a
+	// single call to the API and its response. See Stats.SharedRequests.
+	ShareRequests bool
+
+	// Cache, if set, stores successful responses so that repeated identical requests are served without
+	// calling the API. See NewMemoryCache.
+	Cache Cache
+
+	// Logger, if set, logs the url, status and latency of every request. If nil, nothing is logged.
+	Logger *slog.Logger
+
+	// Debug, if set, receives the url and raw response body of every API call before it is parsed.
+	Debug io.Writer
+
+	shared *clientState
+
+	// endpointErr fails every request of a per-language copy of a client whose Endpoints have no equivalent in
+	// that language. See withLanguage.
+	endpointErr error
+}
+
+// DefaultClient is the Client used by the package-level functions.
+var DefaultClient = &Client{}
+
+func (c *Client) language() string {
+	if c.Language == "" {
+		return DefaultLanguage
+	}
+
+	return c.Language
+}
+
+func (c *Client) searchLimit() int {
+	if c.SearchLimit <= 0 {
+		return DefaultSearchLimit
+	}
+
+	return c.SearchLimit
+}
+
+func (c *Client) summaryLength() int {
+	if c.SummaryLength == 0 {
+		return DefaultSummaryLength
+	}
+
+	return c.SummaryLength
+}
+
+// maxExtractChars is the most characters the API cuts an extract to with exchars.
+const maxExtractChars = 1200
+
+// limitExtract asks the API to cut the extracts requested with params to the client's ExtractChars, if set, and
+// reports whether it did.
+func (c *Client) limitExtract(params map[string]string) bool {
+	if c.ExtractChars <= 0 {
+		return false
+	}
+
+	params["exchars"] = strconv.Itoa(min(c.ExtractChars, maxExtractChars))
+	return true
+}
+
+// apiEllipses are the marks the API ends the extracts it cut with exchars with.
+var apiEllipses = []string{"...", "…"}
+
+// previewLength returns the summary length for the given extract, honoring SummaryRatio when it is set.
+func (c *Client) previewLength(extract string) int {
+	if c.SummaryRatio > 0 && c.SummaryRatio < 1 {
+		return int(float64(len([]rune(extract))) * c.SummaryRatio)
+	}
+
+	return c.summaryLength()
+}
+
+// preview returns the summary of the given extract, truncated to the client's summary length with its ellipsis.
+// An extract the API already cut, having been requested with ExtractChars, isn't truncated again; the API's own
+// mark of the cut is replaced with the client's ellipsis instead.
+func (c *Client) preview(extract string, cut bool) string {
+	ellipsis := c.Ellipsis
+
+	if ellipsis == "" {
+		ellipsis = DefaultEllipsis
+	}
+
+	if !cut {
+		return summarize(extract, c.previewLength(extract), ellipsis)
+	}
+
+	summary := summarize(extract, -1, ellipsis)
+
+	for _, mark := range apiEllipses {
+		if trimmed, ok := strings.CutSuffix(summary, mark); ok {
+			return trimmed + ellipsis
+		}
+	}
+
+	return summary
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+
+	return c.HTTPClient
+}
+
+// endpoints returns the base urls to query, in order of preference.
+func (c *Client) endpoints() ([]string, error) {
+	if c.endpointErr != nil {
+		return nil, c.endpointErr
+	}
+
+	if len(c.Endpoints) > 0 {
+		return c.Endpoints, nil
+	}
+
+	lang := c.language()
+
+	if !languagePattern.MatchString(lang) {
+		return nil, fmt.Errorf("invalid language code %q", lang)
+	}
+
+	project := c.Project
+
+	if project == "" {
+		project = DefaultProject
+	}
+
+	if !projects[project] {
+		return nil, fmt.Errorf("unknown project %q", project)
+	}
+
+	return []string{fmt.Sprintf("https://%s.%s.org", lang, project)}, nil
+}
+
+// apiURL returns the action API endpoint of the client's first endpoint.
+func (c *Client) apiURL() (string, error) {
+	endpoints, err := c.endpoints()
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(endpoints[0], "/") + "/w/api.php", nil
+}
+
+// restURL returns the REST API endpoint for the given path, e.g. "/page/summary/Go", on the client's first
+// endpoint.
+func (c *Client) restURL(path string) (string, error) {
+	endpoints, err := c.endpoints()
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(endpoints[0], "/") + "/api/rest_v1" + path, nil
+}
+
+// restTitle escapes an article title for use as a REST API path segment.
+func restTitle(title string) string {
+	return url.PathEscape(strings.ReplaceAll(title, " ", "_"))
+}
+
+type restError struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// getRESTJSON calls the REST API at the given path and decodes the JSON response into v.
+func (c *Client) getRESTJSON(ctx context.Context, path string, v any) error {
+	restURL, err := c.restURL(path)
+
+	if err != nil {
+		return err
+	}
+
+	if c.streams() {
+		resp, done, err := c.open(ctx, restURL)
+
+		if err != nil {
+			return err
+		}
+
+		defer done()
+
+		if resp.StatusCode != http.StatusOK {
+			// Problem bodies are small, so they are read whole
+			body, _ := io.ReadAll(resp.Body)
+			body = trimBOM(body)
+
+			return c.restFailure(path, resp.StatusCode, body)
+		}
+
+		return c.decodeStream(ctx, resp.Body, v)
+	}
+
+	responseBytes, status, err := c.fetch(ctx, restURL)
+
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return c.restFailure(path, status, responseBytes)
+	}
+
+	return c.decode(restURL, responseBytes, v)
+}
+
+// restFailure returns the error for an unsuccessful REST API response. The REST API reports failures such as
+// missing pages with a JSON problem body. A 404 response for a /page/ path is returned as ErrPageNotFound; for
+// other paths, such as feeds and pageviews, it means the date or resource has no data and is a plain error.
+func (c *Client) restFailure(path string, status int, body []byte) error {
+	c.state().stats.httpErrors.Add(1)
+
+	var restError restError
+
+	decoded := json.Unmarshal(body, &restError) == nil && restError.Detail != ""
+
+	if status == http.StatusNotFound && strings.HasPrefix(path, "/page/") {
+		if decoded {
+			return fmt.Errorf("%w: %s", ErrPageNotFound, restError.Detail)
+		}
+
+		return ErrPageNotFound
+	}
+
+	if decoded {
+		return fmt.Errorf("rest api error (%d): %s", status, restError.Detail)
+	}
+
+	return fmt.Errorf("rest api error: %d %s", status, http.StatusText(status))
+}
+
+// followRedirects asks the API to resolve the titles in params through redirects, unless the client keeps them.
+func (c *Client) followRedirects(params map[string]string) {
+	if !c.KeepRedirects {
+		params["redirects"] = ""
+	}
+}
+
+// requestURL returns the action API url for the given query parameters.
+func (c *Client) requestURL(params map[string]string) (string, error) {
+	apiURL, err := c.apiURL()
+
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+
+	for key, value := range params {
+		q.Set(key, value)
+	}
+
+	// Every request uses the modern response format, in which pages are arrays and flags such as missing
+	// are booleans rather than empty-string markers. It isn't a Client setting: the response types are only
+	// written for this format, and the legacy one, with pages keyed by id and empty-string flags, would decode
+	// into them wrongly rather than fail
+	q.Set("formatversion", "2")
+
+	if c.Variant != "" {
+		q.Set("variant", c.Variant)
+	}
+
+	if c.AnonymousOrigin {
+		q.Set("origin", "*")
+	}
+
+	return apiURL + "?" + q.Encode(), nil
+}
+
+// getJSON calls the API with the given query parameters and decodes the JSON response, in formatversion=2
+// form, into v.
+func (c *Client) getJSON(ctx context.Context, params map[string]string, v any) error {
+	requestURL, err := c.requestURL(params)
+
+	if err != nil {
+		return err
+	}
+
+	if env, ok := v.(enveloped); ok && c.streams() {
+		return c.streamJSON(ctx, requestURL, v, env)
+	}
+
+	responseBytes, status, err := c.fetch(ctx, requestURL)
+
+	if err != nil {
+		return err
+	}
+
+	// The API reports invalid requests in the body, usually with a 200 status
+	err = checkEnvelope(responseBytes, c.FailOnWarnings)
+
+	if err != nil {
+		c.state().stats.apiErrors.Add(1)
+		return err
+	}
+
+	if status != http.StatusOK {
+		c.state().stats.httpErrors.Add(1)
+		return fmt.Errorf("api error: %d %s", status, http.StatusText(status))
+	}
+
+	return c.decode(requestURL, responseBytes, v)
+}
+
+// streamJSON calls the API at the url and decodes the response into v as it is read, checking the envelope v
+// embeds once it is complete.
+func (c *Client) streamJSON(ctx context.Context, requestURL string, v any, env enveloped) error {
+	resp, done, err := c.open(ctx, requestURL)
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	if resp.StatusCode != http.StatusOK {
+		// Error bodies are small, so they are read whole and checked like a buffered response
+		body, _ := io.ReadAll(resp.Body)
+		body = trimBOM(body)
+
+		err = checkEnvelope(body, c.FailOnWarnings)
+
+		if err != nil {
+			c.state().stats.apiErrors.Add(1)
+			return err
+		}
+
+		c.state().stats.httpErrors.Add(1)
+		return fmt.Errorf("api error: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	err = c.decodeStream(ctx, resp.Body, v)
+
+	if err != nil {
+		return err
+	}
+
+	err = env.envelope().check(c.FailOnWarnings)
+
+	if err != nil {
+		c.state().stats.apiErrors.Add(1)
+		return err
+	}
+
+	return nil
+}
+
+// streams reports whether responses can be decoded as they are read. They are read whole instead when the
+// raw body is needed, for the cache, the Debug writer or sharing with identical requests, or no request is made
+// at all.
+func (c *Client) streams() bool {
+	return c.Cache == nil && c.Debug == nil && !c.DryRun && !c.ShareRequests
+}
+
+// open starts a GET request to the url once the client's concurrency limit allows it. The returned function
+// closes the body and frees the slot. The body is also closed as soon as the context is done, so that a read
+// on a stalled connection returns promptly.
+func (c *Client) open(ctx context.Context, requestURL string) (*http.Response, func(), error) {
+	resp, release, err := c.failover(ctx, requestURL)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.limitBody(resp)
+
+	stop := context.AfterFunc(ctx, func() {
+		resp.Body.Close()
+	})
+
+	done := func() {
+		stop()
+		resp.Body.Close()
+		release()
+	}
+
+	return resp, done, nil
+}
+
+// fetch returns the body and status code of a GET request to the url, serving it from the client's cache
+// when possible.
+func (c *Client) fetch(ctx context.Context, requestURL string) ([]byte, int, error) {
+	if c.DryRun {
+		c.recordDryRun(ctx, requestURL)
+		return []byte("{}"), http.StatusOK, nil
+	}
+
+	if c.Cache != nil {
+		if body, ok := c.Cache.Get(c.cacheKey(requestURL)); ok {
+			c.state().stats.cacheHits.Add(1)
+			return body, http.StatusOK, nil
+		}
+
+		c.state().stats.cacheMisses.Add(1)
+	}
+
+	if c.ShareRequests {
+		return c.fetchShared(ctx, requestURL)
+	}
+
+	return c.fetchBody(ctx, requestURL)
+}
+
+// cacheKey returns the key the response to the url is cached under. REST requests carry the client's Variant only
+// in the Accept-Language header, so it is added to the url for responses in different variants to be kept apart.
+func (c *Client) cacheKey(requestURL string) string {
+	if c.Variant == "" {
+		return requestURL
+	}
+
+	return requestURL + " variant=" + c.Variant
+}
+
+// fetchBody returns the body and status code of a GET request to the url.
+func (c *Client) fetchBody(ctx context.Context, requestURL string) ([]byte, int, error) {
+	resp, done, err := c.open(ctx, requestURL)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer done()
+
+	responseBytes, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		c.state().stats.networkErrors.Add(1)
+
+		// Report the cancellation rather than the read on the closed body it caused
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+
+		return nil, 0, err
+	}
+
+	responseBytes = trimBOM(responseBytes)
+
+	c.debug(requestURL, responseBytes)
+
+	return responseBytes, resp.StatusCode, nil
+}
+
+// utf8BOM is the byte order mark some proxies prefix UTF-8 response bodies with, which JSON doesn't allow.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// trimBOM removes a leading byte order mark, and the whitespace around it, from a response body so that it parses
+// as JSON.
+func trimBOM(body []byte) []byte {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+
+	if !bytes.HasPrefix(trimmed, utf8BOM) {
+		return body
+	}
+
+	return bytes.TrimLeft(trimmed[len(utf8BOM):], " \t\r\n")
+}
+
+// skipBOM reads past a leading byte order mark, and the whitespace around it, of a response body being streamed.
+func skipBOM(reader *bufio.Reader) {
+	for {
+		next, err := reader.Peek(len(utf8BOM))
+
+		if len(next) > 0 && strings.ContainsRune(" \t\r\n", rune(next[0])) {
+			reader.Discard(1)
+			continue
+		}
+
+		if err == nil && bytes.Equal(next, utf8BOM) {
+			reader.Discard(len(utf8BOM))
+			continue
+		}
+
+		return
+	}
+}
+
+// limitedBody is a response body that fails with ErrResponseTooLarge once more than its limit has been read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	// Read at most one byte past the limit, which is enough to tell that it was exceeded
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+
+	if b.remaining < 0 {
+		return n, ErrResponseTooLarge
+	}
+
+	return n, err
+}
+
+// limitBody applies the client's MaxResponseBytes to the response body.
+func (c *Client) limitBody(resp *http.Response) {
+	if c.MaxResponseBytes > 0 {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: c.MaxResponseBytes}
+	}
+}
+
+// decodeStream decodes a successful response into v straight from its body.
+func (c *Client) decodeStream(ctx context.Context, body io.Reader, v any) error {
+	reader := bufio.NewReader(body)
+
+	skipBOM(reader)
+
+	err := json.NewDecoder(reader).Decode(v)
+
+	if err == nil {
+		return nil
+	}
+
+	// Report the cancellation rather than the read on the closed body it caused
+	if ctx.Err() != nil {
+		c.state().stats.networkErrors.Add(1)
+		return ctx.Err()
+	}
+
+	c.state().stats.decodeErrors.Add(1)
+	return err
+}
+
+// decode unmarshals a successful response into v and, once it is known to be valid, stores it in the cache.
+func (c *Client) decode(requestURL string, body []byte, v any) error {
+	err := json.Unmarshal(body, v)
+
+	if err != nil {
+		c.state().stats.decodeErrors.Add(1)
+		return err
+	}
+
+	if c.Cache != nil && !c.DryRun {
+		c.Cache.Set(c.cacheKey(requestURL), body)
+	}
+
+	return nil
+}
+
+// debug writes the url and raw response body of an API call to the client's Debug writer, if any.
+func (c *Client) debug(rawURL string, body []byte) {
+	if c.Debug == nil {
+		return
+	}
+
+	fmt.Fprintf(c.Debug, "GET %s\n%s\n\n", rawURL, body)
+}
+
+// recordDryRun reports a request url built in dry-run mode.
+func (c *Client) recordDryRun(ctx context.Context, requestURL string) {
+	state := c.state()
+
+	state.mu.Lock()
+	state.dryRunURLs = append(state.dryRunURLs, requestURL)
+	state.mu.Unlock()
+
+	if c.Debug != nil {
+		fmt.Fprintf(c.Debug, "GET %s\n\n", requestURL)
+	}
+
+	if c.Logger != nil {
+		c.Logger.LogAttrs(ctx, slog.LevelInfo, "wikipedia dry run request", slog.String("url", requestURL))
+	}
+}
+
+// DryRunURLs returns the request urls built so far in dry-run mode, in the order they were built.
+func (c *Client) DryRunURLs() []string {
+	state := c.state()
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return append([]string(nil), state.dryRunURLs...)
+}
@@ -0,0 +1,279 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client whose requests are all answered by the handler, without retries.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Client{Endpoints: []string{server.URL}, MaxRetries: -1}
+}
+
+// serveBody returns a handler answering every request with the body as JSON.
+func serveBody(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+}
+
+// readFixture returns the content of the named file in testdata.
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(body)
+}
+
+// serveFixture returns a handler answering every request with the named file in testdata.
+func serveFixture(t *testing.T, name string) http.HandlerFunc {
+	t.Helper()
+
+	return serveBody(readFixture(t, name))
+}
+
+// serveFixtures returns a handler answering each request with the fixture of the first route its query matches,
+// a route being a parameter and its value, e.g. "list=search". Requests matching no route fail the test.
+func serveFixtures(t *testing.T, routes [][2]string) http.HandlerFunc {
+	t.Helper()
+
+	bodies := make([]string, 0, len(routes))
+
+	for _, route := range routes {
+		bodies = append(bodies, readFixture(t, route[1]))
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		for i, route := range routes {
+			key, value, _ := strings.Cut(route[0], "=")
+
+			if query.Has(key) && query.Get(key) == value {
+				serveBody(bodies[i])(w, r)
+				return
+			}
+		}
+
+		t.Errorf("unexpected request %s", r.URL)
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+// queryRecorder records the queries of the requests a test server answers.
+type queryRecorder struct {
+	mu      sync.Mutex
+	queries []url.Values
+}
+
+// wrap returns the handler recording the query of every request before answering it.
+func (r *queryRecorder) wrap(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		r.queries = append(r.queries, req.URL.Query())
+		r.mu.Unlock()
+
+		handler(w, req)
+	}
+}
+
+// last returns the query of the latest request.
+func (r *queryRecorder) last(t *testing.T) url.Values {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.queries) == 0 {
+		t.Fatal("no request was made")
+	}
+
+	return r.queries[len(r.queries)-1]
+}
+
+// bufferedClient turns the client's streaming decoding off by giving it a cache, so that responses are read
+// whole before they are parsed.
+func bufferedClient(c *Client) *Client {
+	c.Cache = NewMemoryCache(time.Minute)
+	return c
+}
+
+func TestBOMPrefixedResponse(t *testing.T) {
+	const page = `{"batchcomplete": true, "query": {"pages": [{"pageid": 25039021, "ns": 0, "title": "Go (programming language)"}]}}`
+
+	tests := []struct {
+		name     string
+		body     string
+		buffered bool
+	}{
+		{"streamed bom", "\xef\xbb\xbf" + page, false},
+		{"streamed bom and whitespace", "\r\n \xef\xbb\xbf " + page, false},
+		{"buffered bom", "\xef\xbb\xbf" + page, true},
+		{"buffered bom and whitespace", "\r\n \xef\xbb\xbf " + page, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveBody(test.body))
+
+			if test.buffered {
+				bufferedClient(client)
+			}
+
+			exists, err := client.ArticleExists(context.Background(), "Go (programming language)")
+
+			if err != nil {
+				t.Fatalf("ArticleExists() error = %v", err)
+			}
+
+			if !exists {
+				t.Error("ArticleExists() = false, want true")
+			}
+		})
+	}
+}
+
+func TestCancelDuringBodyRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		buffered bool
+	}{
+		{"streamed", false},
+		{"buffered", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			release := make(chan struct{})
+
+			// Send the start of a response, then stall as a flaky connection would
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"batchcomplete": true, "query": {"pages": [`))
+				w.(http.Flusher).Flush()
+
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+			})
+
+			t.Cleanup(func() { close(release) })
+
+			if test.buffered {
+				bufferedClient(client)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+
+			_, err := client.ArticleExists(ctx, "Go (programming language)")
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("ArticleExists() error = %v, want %v", err, context.DeadlineExceeded)
+			}
+
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("ArticleExists() returned after %v, want soon after the deadline", elapsed)
+			}
+		})
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	// A valid response, padded inside the object so that it can't be decoded before the limit is reached
+	body := `{"batchcomplete": true, "padding": "` + strings.Repeat("x", 64<<10) + `", "query": {"pages": [` +
+		`{"pageid": 25039021, "ns": 0, "title": "Go (programming language)"}]}}`
+
+	tests := []struct {
+		name     string
+		limit    int64
+		buffered bool
+		wantErr  error
+	}{
+		{"streamed over the limit", 8 << 10, false, ErrResponseTooLarge},
+		{"buffered over the limit", 8 << 10, true, ErrResponseTooLarge},
+		{"streamed at the limit", int64(len(body)), false, nil},
+		{"buffered at the limit", int64(len(body)), true, nil},
+		{"unlimited", 0, false, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveBody(body))
+
+			client.MaxResponseBytes = test.limit
+
+			if test.buffered {
+				bufferedClient(client)
+			}
+
+			exists, err := client.ArticleExists(context.Background(), "Go (programming language)")
+
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("ArticleExists() error = %v, want %v", err, test.wantErr)
+			}
+
+			if test.wantErr == nil && !exists {
+				t.Error("ArticleExists() = false, want true")
+			}
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	const extract = "Go is a high-level general-purpose programming language that is statically typed and compiled."
+
+	tests := []struct {
+		name   string
+		client Client
+		text   string
+		cut    bool
+		want   string
+	}{
+		{"fits", Client{}, extract, false, extract},
+		{"truncated on the client", Client{SummaryLength: 20}, extract, false, "Go is a high-level..."},
+		{"client ellipsis", Client{SummaryLength: 20, Ellipsis: "…"}, extract, false, "Go is a high-level…"},
+		{
+			"not cut by the api despite ExtractChars",
+			Client{SummaryLength: 20, ExtractChars: 40},
+			extract, false, "Go is a high-level...",
+		},
+		{"cut by the api", Client{SummaryLength: 20, ExtractChars: 40}, "Go is a high-level general-purpose...", true, "Go is a high-level general-purpose..."},
+		{
+			"api mark replaced with the client ellipsis",
+			Client{ExtractChars: 40, Ellipsis: " [more]"},
+			"Go is a high-level general-purpose...", true, "Go is a high-level general-purpose [more]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.client.preview(test.text, test.cut); got != test.want {
+				t.Errorf("preview() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package dwiki
+
+import (
+	"context"
+	"unicode"
+)
+
+// scriptLanguages maps the scripts recognized by DetectLanguage to the language edition written in them.
+// Han is handled separately, as it is shared by Chinese and Japanese.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	lang   string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Armenian, "hy"},
+	{unicode.Georgian, "ka"},
+}
+
+// DetectLanguage makes a best-effort guess at the Wikipedia language edition for the given text from the script
+// it is written in, e.g. "ru" for Cyrillic. Chinese characters map to "zh" unless the text also contains kana,
+// which marks it as Japanese. Text in the Latin script, or in no recognized script, maps to DefaultLanguage.
+func DetectLanguage(text string) string {
+	counts := make(map[string]int)
+	han := 0
+
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			han++
+			continue
+		}
+
+		for _, script := range scriptLanguages {
+			if unicode.Is(script.script, r) {
+				counts[script.lang]++
+				break
+			}
+		}
+	}
+
+	if counts["ja"] > 0 {
+		return "ja"
+	}
+
+	lang := DefaultLanguage
+	best := 0
+
+	// Pick the most common script, keeping the table's order on ties
+	for _, script := range scriptLanguages {
+		if counts[script.lang] > best {
+			lang = script.lang
+			best = counts[script.lang]
+		}
+	}
+
+	if han > best {
+		return "zh"
+	}
+
+	return lang
+}
+
+// DetectAndSearch searches the language edition detected for the topic using DefaultClient.
+func DetectAndSearch(topic string, opts ...Option) ([]Article, string, error) {
+	return DefaultClient.DetectAndSearch(context.Background(), topic, opts...)
+}
+
+// DetectAndSearch searches for the topic in the language edition guessed by DetectLanguage and returns the results
+// along with the language searched. A Language set on the client overrides the guess.
+func (c *Client) DetectAndSearch(ctx context.Context, topic string, opts ...Option) ([]Article, string, error) {
+	lang := c.Language
+
+	if lang == "" {
+		lang = DetectLanguage(topic)
+	}
+
+	articles, err := c.withLanguage(lang).Search(ctx, topic, opts...)
+
+	if err != nil {
+		return nil, lang, err
+	}
+
+	return articles, lang, nil
+}
@@ -0,0 +1,303 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type linkedPagesResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Gplcontinue string `json:"gplcontinue"`
+		Continue    string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		Pages []struct {
+			Pageid  int    `json:"pageid"`
+			Ns      int    `json:"ns"`
+			Title   string `json:"title"`
+			Missing bool   `json:"missing"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+type backlinksResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Blcontinue string `json:"blcontinue"`
+		Continue   string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		Backlinks []struct {
+			Pageid int    `json:"pageid"`
+			Ns     int    `json:"ns"`
+			Title  string `json:"title"`
+		} `json:"backlinks"`
+	} `json:"query"`
+}
+
+// maxBacklinkCount is the most backlinks counted for each candidate by ResolveAmbiguous, the most the API
+// returns in one request.
+const maxBacklinkCount = 500
+
+// IsDisambiguation reports whether the page with the given id is a disambiguation page using DefaultClient.
+func IsDisambiguation(pageId int) (bool, error) {
+	return DefaultClient.IsDisambiguation(context.Background(), pageId)
+}
+
+// IsDisambiguation reports whether the page with the given id is a disambiguation page.
+func (c *Client) IsDisambiguation(ctx context.Context, pageId int) (bool, error) {
+	disambiguations, err := c.getDisambiguations(ctx, []int{pageId})
+
+	if err != nil {
+		return false, err
+	}
+
+	isDisambiguation, ok := disambiguations[pageId]
+
+	if !ok {
+		return false, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	return isDisambiguation, nil
+}
+
+// GetDisambiguationOptions returns the articles listed on the disambiguation page with the given id using
+// DefaultClient.
+func GetDisambiguationOptions(pageId int) ([]Article, error) {
+	return DefaultClient.GetDisambiguationOptions(context.Background(), pageId)
+}
+
+// GetDisambiguationOptions returns the articles the disambiguation page with the given id links to, sorted by
+// title, so that the user can pick the one they meant. Links to pages that don't exist, or outside the main
+// namespace, are left out. It returns ErrNotDisambiguation if the page isn't a disambiguation page.
+func (c *Client) GetDisambiguationOptions(ctx context.Context, pageId int) ([]Article, error) {
+	isDisambiguation, err := c.IsDisambiguation(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDisambiguation {
+		return nil, fmt.Errorf("%w: %d", ErrNotDisambiguation, pageId)
+	}
+
+	return c.getLinkedArticles(ctx, pageId)
+}
+
+// getLinkedArticles returns the existing articles the page with the given id links to, sorted by title,
+// following continuation until all have been read.
+func (c *Client) getLinkedArticles(ctx context.Context, pageId int) ([]Article, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["generator"] = "links"
+	params["gplnamespace"] = "0"
+	params["gpllimit"] = "max"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	options := make([]Article, 0)
+
+	for {
+		var linkedPagesResponse linkedPagesResponse
+
+		err := c.getJSON(ctx, params, &linkedPagesResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range linkedPagesResponse.Query.Pages {
+			if page.Missing {
+				continue
+			}
+
+			options = append(options, Article{Title: c.decodeText(page.Title), PageID: page.Pageid})
+		}
+
+		if linkedPagesResponse.Continue.Gplcontinue == "" {
+			break
+		}
+
+		params["gplcontinue"] = linkedPagesResponse.Continue.Gplcontinue
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Title < options[j].Title
+	})
+
+	return options, nil
+}
+
+// getDisambiguations looks up the disambiguation page prop for the given page ids in as few requests as the API
+// allows. The returned map holds an entry for every page the API returned, set to true for disambiguation pages.
+func (c *Client) getDisambiguations(ctx context.Context, pageIds []int) (map[int]bool, error) {
+	disambiguations := make(map[int]bool)
+
+	for start := 0; start < len(pageIds); start += maxPageIds {
+		end := min(start+maxPageIds, len(pageIds))
+
+		ids := make([]string, 0, end-start)
+
+		for _, pageId := range pageIds[start:end] {
+			ids = append(ids, strconv.Itoa(pageId))
+		}
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "pageprops"
+		params["ppprop"] = "disambiguation"
+		params["redirects"] = ""
+		params["format"] = "json"
+		params["pageids"] = strings.Join(ids, "|")
+
+		var categoryResponse categoryResponse
+
+		err := c.getJSON(ctx, params, &categoryResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range categoryResponse.Query.Pages {
+			if page.Missing {
+				continue
+			}
+
+			// The disambiguation prop is present, with an empty value, only on disambiguation pages
+			disambiguations[page.Pageid] = page.PageProps != nil && page.PageProps.Disambiguation != nil
+		}
+	}
+
+	return disambiguations, nil
+}
+
+// ResolveAmbiguous returns the most likely meaning of an ambiguous topic and the ranked alternatives using
+// DefaultClient.
+func ResolveAmbiguous(topic string) (*Article, []Article, error) {
+	return DefaultClient.ResolveAmbiguous(context.Background(), topic)
+}
+
+// ResolveAmbiguous looks up the page titled after the topic and, if it is a disambiguation page, ranks the articles
+// it lists by how many articles link to them, the most linked-to being usually the meaning intended. It returns
+// the details of the top article and the others, most linked-to first, with ties kept in title order. Links are
+// counted up to 500 per article, with one request each, so very popular articles tie. A topic whose page isn't a
+// disambiguation page returns that article and no alternatives. It returns ErrPageNotFound when there is no
+// page with the title.
+func (c *Client) ResolveAmbiguous(ctx context.Context, topic string) (*Article, []Article, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "pageprops"
+	params["ppprop"] = "disambiguation"
+	params["titles"] = topic
+	c.followRedirects(params)
+	params["format"] = "json"
+
+	var categoryResponse categoryResponse
+
+	err := c.getJSON(ctx, params, &categoryResponse)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(categoryResponse.Query.Pages) == 0 || categoryResponse.Query.Pages[0].Missing {
+		return nil, nil, fmt.Errorf("%w: %s", ErrPageNotFound, topic)
+	}
+
+	page := categoryResponse.Query.Pages[0]
+
+	if page.PageProps == nil || page.PageProps.Disambiguation == nil {
+		article, err := c.GetArticleDetails(ctx, page.Pageid)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return article, []Article{}, nil
+	}
+
+	options, err := c.GetDisambiguationOptions(ctx, page.Pageid)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(options) == 0 {
+		return nil, nil, fmt.Errorf("disambiguation page %d lists no articles", page.Pageid)
+	}
+
+	backlinks := make(map[int]int)
+
+	for _, option := range options {
+		backlinks[option.PageID], err = c.countBacklinks(ctx, option.PageID)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		return backlinks[options[i].PageID] > backlinks[options[j].PageID]
+	})
+
+	article, err := c.GetArticleDetails(ctx, options[0].PageID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return article, options[1:], nil
+}
+
+// countBacklinks returns the number of articles linking to the page with the given id, up to maxBacklinkCount.
+// Redirects to the page are not counted as links.
+func (c *Client) countBacklinks(ctx context.Context, pageId int) (int, error) {
+	backlinks, err := c.getBacklinks(ctx, "blpageid", strconv.Itoa(pageId))
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(backlinks), nil
+}
+
+// getBacklinks returns up to maxBacklinkCount articles linking to the page selected by the given parameter, either
+// blpageid or bltitle, leaving out redirects to the page.
+func (c *Client) getBacklinks(ctx context.Context, key, value string) ([]Article, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["list"] = "backlinks"
+	params[key] = value
+	params["blnamespace"] = "0"
+	params["blfilterredir"] = "nonredirects"
+	params["bllimit"] = strconv.Itoa(maxBacklinkCount)
+	params["format"] = "json"
+
+	var backlinksResponse backlinksResponse
+
+	err := c.getJSON(ctx, params, &backlinksResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	backlinks := make([]Article, 0, len(backlinksResponse.Query.Backlinks))
+
+	for _, backlink := range backlinksResponse.Query.Backlinks {
+		backlinks = append(backlinks, Article{Title: c.decodeText(backlink.Title), PageID: backlink.Pageid})
+	}
+
+	return backlinks, nil
+}
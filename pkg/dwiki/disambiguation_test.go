@@ -0,0 +1,128 @@
+package dwiki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIsDisambiguation(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		pageId  int
+		want    bool
+		wantErr error
+	}{
+		{"disambiguation page", "pageprops_disambiguation.json", 1130433, true, nil},
+		{"article", "pageprops_article.json", 19694, false, nil},
+		{"missing page", "pageprops_missing.json", 999999999, false, ErrPageNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var recorder queryRecorder
+
+			client := newTestClient(t, recorder.wrap(serveFixture(t, test.fixture)))
+
+			got, err := client.IsDisambiguation(context.Background(), test.pageId)
+
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("IsDisambiguation() error = %v, want %v", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("IsDisambiguation() = %v, want %v", got, test.want)
+			}
+
+			query := recorder.last(t)
+
+			if query.Get("prop") != "pageprops" || query.Get("ppprop") != "disambiguation" {
+				t.Errorf("request query = %s, want prop=pageprops&ppprop=disambiguation", query.Encode())
+			}
+		})
+	}
+}
+
+// serveLargeSearch answers searches with total results whose page ids count up from 1, and page prop lookups the
+// way the API does, reading at most 50 page ids. The page with the id disambiguation is a disambiguation page.
+func serveLargeSearch(t *testing.T, total, disambiguation int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case query.Get("list") == "search":
+			offset, _ := strconv.Atoi(query.Get("sroffset"))
+			limit, _ := strconv.Atoi(query.Get("srlimit"))
+			end := min(offset+limit, total)
+
+			results := make([]map[string]any, 0, end-offset)
+
+			for id := offset + 1; id <= end; id++ {
+				results = append(results, map[string]any{"ns": 0, "title": fmt.Sprintf("Result %d", id), "pageid": id})
+			}
+
+			response := map[string]any{"batchcomplete": true, "query": map[string]any{"search": results}}
+
+			if end < total {
+				response["continue"] = map[string]any{"sroffset": end, "continue": "-||"}
+			}
+
+			json.NewEncoder(w).Encode(response)
+		case query.Get("prop") == "pageprops":
+			ids := strings.Split(query.Get("pageids"), "|")
+
+			if len(ids) > 50 {
+				ids = ids[:50]
+			}
+
+			pages := make([]map[string]any, 0, len(ids))
+
+			for _, value := range ids {
+				id, _ := strconv.Atoi(value)
+				page := map[string]any{"pageid": id, "ns": 0, "title": fmt.Sprintf("Result %d", id)}
+
+				if id == disambiguation {
+					page["pageprops"] = map[string]any{"disambiguation": ""}
+				}
+
+				pages = append(pages, page)
+			}
+
+			json.NewEncoder(w).Encode(map[string]any{"batchcomplete": true, "query": map[string]any{"pages": pages}})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSearchFiltersMoreThan50Results(t *testing.T) {
+	client := newTestClient(t, serveLargeSearch(t, 130, 75))
+
+	articles, err := client.SearchAll(context.Background(), "result", 120)
+
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+
+	if len(articles) != 120 {
+		t.Fatalf("SearchAll() returned %d articles, want 120", len(articles))
+	}
+
+	for _, article := range articles {
+		if article.PageID == 75 {
+			t.Error("SearchAll() kept the disambiguation page 75")
+		}
+	}
+
+	if last := articles[len(articles)-1].PageID; last != 121 {
+		t.Errorf("SearchAll() last page id = %d, want 121", last)
+	}
+}
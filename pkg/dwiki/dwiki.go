@@ -12,306 +12,534 @@ Example usage:
 	}
 
 This will search for the term "golang" on Wikipedia and print a summary of the first search result to the console.
+
+The package-level functions use DefaultClient. To query another language edition or change the
+search and summary limits, create a Client and call its methods instead:
+
+	client := &dwiki.Client{Language: "fr", SearchLimit: 5}
+
+	err := client.GetWikiArticleSummary(context.Background(), "paris", os.Stdout)
 */
 package dwiki
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
 )
 
 type searchResponse struct {
-	Batchcomplete string `json:"batchcomplete"`
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
 	Continue      struct {
 		Sroffset int    `json:"sroffset"`
 		Continue string `json:"continue"`
 	} `json:"continue"`
 	Query struct {
 		Searchinfo struct {
-			Totalhits int `json:"totalhits"`
+			Totalhits  int    `json:"totalhits"`
+			Suggestion string `json:"suggestion"`
 		} `json:"searchinfo"`
 		Search []struct {
 			Ns              int    `json:"ns"`
 			Title           string `json:"title"`
 			Pageid          int    `json:"pageid"`
 			Wordcount       int    `json:"wordcount"`
+			Size            int    `json:"size"`
+			Timestamp       string `json:"timestamp"`
+			Snippet         string `json:"snippet"`
+			TitleSnippet    string `json:"titlesnippet"`
 			CategorySnippet string `json:"categorysnippet"`
+			RedirectTitle   string `json:"redirecttitle"`
+			RedirectSnippet string `json:"redirectsnippet"`
+			SectionTitle    string `json:"sectiontitle"`
+			SectionSnippet  string `json:"sectionsnippet"`
+			IsFileMatch     bool   `json:"isfilematch"`
 		} `json:"search"`
 	} `json:"query"`
 }
 
 type categoryResponse struct {
-	Batchcomplete string `json:"batchcomplete"`
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
 	Query         struct {
-		Pages map[string]struct {
+		Pages []struct {
 			Pageid     int    `json:"pageid"`
 			Ns         int    `json:"ns"`
 			Title      string `json:"title"`
+			Missing    bool   `json:"missing"`
 			Categories []struct {
 				Ns    int    `json:"ns"`
 				Title string `json:"title"`
 			} `json:"categories,omitempty"`
 			PageProps *struct {
-				Disambiguation string `json:"disambiguation,omitempty"`
+				Disambiguation *string `json:"disambiguation,omitempty"`
 			} `json:"pageprops,omitempty"`
 		} `json:"pages"`
 	} `json:"query"`
 }
 
 type extractResponse struct {
-	Batchcomplete string `json:"batchcomplete"`
-	Query         struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+
+	// Continue holds every continuation parameter, excontinue and the generic continue among them, so that they
+	// can all be sent back
+	Continue map[string]json.RawMessage `json:"continue"`
+	Query    struct {
 		Normalized []struct {
 			From string `json:"from"`
 			To   string `json:"to"`
 		} `json:"normalized"`
-		Pages map[string]struct {
-			Pageid  int    `json:"pageid"`
-			Ns      int    `json:"ns"`
-			Title   string `json:"title"`
-			Extract string `json:"extract"`
-			FullURL string `json:"fullurl"`
-		} `json:"pages"`
+		Redirects []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"redirects"`
+		Pages []extractPage `json:"pages"`
 	} `json:"query"`
 	Limits struct {
 		Extracts int `json:"extracts"`
 	} `json:"limits"`
 }
 
-// GetMatchingArticles searches for articles matching the given topic and writes the results to the given writer.
-// It returns a map of article titles with their corresponding index.
-func GetMatchingArticles(topic string, writer io.Writer) (map[int]int, error) {
-	const url = "https://en.wikipedia.org/w/api.php"
+type extractPage struct {
+	Pageid  int    `json:"pageid"`
+	Ns      int    `json:"ns"`
+	Title   string `json:"title"`
+	Missing bool   `json:"missing"`
+	Invalid bool   `json:"invalid"`
+	Extract string `json:"extract"`
+	FullURL string `json:"fullurl"`
 
-	options := make(map[int]int)
+	DisplayTitle string `json:"displaytitle"`
+	Length       int    `json:"length"`
 
-	params := make(map[string]string)
+	// cut reports whether the extract was requested with exchars, so that the API has already truncated it.
+	cut bool
+}
 
-	params["action"] = "query"
-	params["list"] = "search"
-	params["srsearch"] = topic
-	params["format"] = "json"
-	params["srlimit"] = "20"
-	params["srprop"] = "wordcount|categorysnippet"
+// GetMatchingArticles searches for articles matching the given topic and writes the results to the given writer
+// using DefaultClient. It returns a map of article page ids with their corresponding index.
+func GetMatchingArticles(topic string, writer io.Writer, opts ...Option) (map[int]int, error) {
+	return DefaultClient.GetMatchingArticles(context.Background(), topic, writer, opts...)
+}
 
-	// Call the API
-	httpClient := http.Client{}
+// GetMatchingArticles searches for articles matching the given topic and writes the results to the given writer.
+// It returns a map of article page ids with their corresponding index.
+func (c *Client) GetMatchingArticles(
+	ctx context.Context, topic string, writer io.Writer, opts ...Option,
+) (map[int]int, error) {
+	options := make(map[int]int)
 
-	req, err := http.NewRequest("GET", url, nil)
+	articles, err := c.Search(ctx, topic, opts...)
 
 	if err != nil {
 		return options, err
 	}
 
-	q := req.URL.Query()
+	// If there are no search results, print a message
+	if len(articles) == 0 {
+		_, err = io.WriteString(writer, c.messages().NoResults+"\n\n")
+		return options, err
+	}
 
-	for key, value := range params {
-		q.Set(key, value)
+	// Print the titles of the search results
+	resultString := c.messages().SearchResults + "\n"
+
+	for i, article := range articles {
+		resultString += fmt.Sprintf("%d. %s\n", i+1, article.Title)
+		options[i+1] = article.PageID
+	}
+
+	_, err = io.WriteString(writer, resultString)
+
+	if err != nil {
+		return nil, err
 	}
 
-	req.URL.RawQuery = q.Encode()
+	return options, nil
+}
 
-	resp, err := httpClient.Do(req)
+// GetArticleDetails fetches the article with the given page id using DefaultClient.
+func GetArticleDetails(pageId int) (*Article, error) {
+	return DefaultClient.GetArticleDetails(context.Background(), pageId)
+}
+
+// GetArticleDetails fetches the article with the given page id. The returned article carries the full
+// intro extract along with a preview limited to the client's summary length.
+func (c *Client) GetArticleDetails(ctx context.Context, pageId int) (*Article, error) {
+	page, err := c.getExtract(ctx, pageId)
 
 	if err != nil {
-		return options, err
+		return nil, err
+	}
+
+	return c.extractArticle(page), nil
+}
+
+// extractArticle converts a page with its intro extract into an Article with a preview.
+func (c *Client) extractArticle(page extractPage) *Article {
+	return &Article{
+		Title:        c.decodeText(page.Title),
+		PageID:       page.Pageid,
+		URL:          page.FullURL,
+		DisplayTitle: page.DisplayTitle,
+		Length:       page.Length,
+		Extract:      page.Extract,
+		Preview:      c.preview(page.Extract, page.cut),
 	}
+}
 
-	defer resp.Body.Close()
+// GetArticleParagraphs returns the intro paragraphs of the article with the given page id using DefaultClient.
+func GetArticleParagraphs(pageId int) ([]string, error) {
+	return DefaultClient.GetArticleParagraphs(context.Background(), pageId)
+}
 
-	// Write response to console
-	responseBytes, err := io.ReadAll(resp.Body)
+// GetArticleParagraphs returns the intro paragraphs of the article with the given page id, untruncated and
+// with blank lines removed.
+func (c *Client) GetArticleParagraphs(ctx context.Context, pageId int) ([]string, error) {
+	page, err := c.getExtract(ctx, pageId)
 
 	if err != nil {
-		return options, err
+		return nil, err
 	}
 
-	var searchResponse searchResponse
+	return splitParagraphs(page.Extract), nil
+}
+
+// GetFirstSentence returns the first sentence of the article with the given page id using DefaultClient.
+func GetFirstSentence(pageId int) (string, error) {
+	return DefaultClient.GetFirstSentence(context.Background(), pageId)
+}
 
-	err = json.Unmarshal(responseBytes, &searchResponse)
+// GetFirstSentence returns the first sentence of the intro of the article with the given page id. Periods ending
+// abbreviations such as "Dr." or "U.S." are not treated as the end of the sentence.
+func (c *Client) GetFirstSentence(ctx context.Context, pageId int) (string, error) {
+	page, err := c.getExtract(ctx, pageId)
 
 	if err != nil {
-		return options, err
+		return "", err
 	}
 
-	// If there are no search results, print a message
-	if len(searchResponse.Query.Search) == 0 {
-		writer.Write([]byte("No search results found.\n\n"))
-		return options, nil
+	paragraphs := splitParagraphs(page.Extract)
+
+	if len(paragraphs) == 0 {
+		return "", ErrNoExtract
 	}
 
-	// Get the categories for the search results to eliminate disambiguation pages
-	categoryQueryUrl := "https://en.wikipedia.org/w/api.php?action=query&prop=pageprops&ppprop=disambiguation&redirects&format=json&pageids="
+	return firstSentence(paragraphs[0]), nil
+}
 
-	for _, result := range searchResponse.Query.Search {
-		categoryQueryUrl += fmt.Sprintf("%d|", result.Pageid)
+// getExtract fetches the intro extract and url of the article with the given page id.
+func (c *Client) getExtract(ctx context.Context, pageId int) (extractPage, error) {
+	params := make(map[string]string)
+
+	params["pageids"] = strconv.Itoa(pageId)
+
+	page, err := c.queryExtract(ctx, params)
+
+	if errors.Is(err, ErrPageNotFound) {
+		return extractPage{}, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
 	}
 
-	// Get rid of the last pipe character
-	categoryQueryUrl = categoryQueryUrl[:len(categoryQueryUrl)-1]
+	return page, err
+}
+
+// getExtractByTitle fetches the intro extract and url of the article with the given title, following redirects unless
+// the client keeps them.
+func (c *Client) getExtractByTitle(ctx context.Context, title string) (extractPage, error) {
+	params := make(map[string]string)
+
+	params["titles"] = c.normalizeTitle(title)
+	c.followRedirects(params)
+
+	return c.queryExtract(ctx, params)
+}
 
-	req, err = http.NewRequest("GET", categoryQueryUrl, nil)
+// queryExtract fetches the intro extract and url of the single page selected by the given parameters. It returns
+// ErrPageNotFound if the page doesn't exist and ErrNoExtract if it has no intro.
+func (c *Client) queryExtract(ctx context.Context, params map[string]string) (extractPage, error) {
+	page, err := c.queryPage(ctx, params)
 
 	if err != nil {
-		return options, err
+		return extractPage{}, err
 	}
 
-	resp, err = httpClient.Do(req)
+	if page.Missing || page.Invalid {
+		return extractPage{}, ErrPageNotFound
+	}
 
-	if err != nil {
-		return options, err
+	if page.Extract == "" {
+		return extractPage{}, ErrNoExtract
 	}
 
-	defer resp.Body.Close()
+	return page, nil
+}
 
-	responseBytes, err = io.ReadAll(resp.Body)
+// queryPage fetches the info and cleaned intro extract of the single page selected by the given parameters. A page
+// that is missing or has no intro is returned as such rather than as an error.
+func (c *Client) queryPage(ctx context.Context, params map[string]string) (extractPage, error) {
+	params["action"] = "query"
+	params["prop"] = "info|extracts"
+	params["exlimit"] = "max"
+	params["explaintext"] = ""
+	params["exintro"] = ""
+	params["inprop"] = "url|displaytitle"
+	cut := c.limitExtract(params)
+	params["format"] = "json"
+
+	extractResponse, err := c.getExtracts(ctx, params)
 
 	if err != nil {
-		return options, err
+		return extractPage{}, err
 	}
 
-	var categoryResponse categoryResponse
+	if len(extractResponse.Query.Pages) == 0 {
+		return extractPage{}, ErrPageNotFound
+	}
+
+	page := extractResponse.Query.Pages[0]
+
+	page.Extract = c.cleanIntro(page.Extract)
+	page.cut = cut
+
+	return page, nil
+}
+
+// getExtracts calls the API with the given extracts query parameters. For full extracts, requested without
+// exintro, it follows the continuation the API uses when an extract doesn't fit in one response, sending every
+// continuation parameter back and appending the text of each page found in a continuation response to the page's
+// extract in the first response, which is returned. Intro extracts are returned as the first response holds them.
+func (c *Client) getExtracts(ctx context.Context, params map[string]string) (*extractResponse, error) {
+	var extracts extractResponse
 
-	err = json.Unmarshal(responseBytes, &categoryResponse)
+	err := c.getJSON(ctx, params, &extracts)
 
 	if err != nil {
-		return options, err
+		return nil, err
 	}
 
-	// Print the titles of the search results
-	resultString := "Search results:\n"
+	if _, intro := params["exintro"]; intro {
+		return &extracts, nil
+	}
 
-	num := 1
+	next := extracts.Continue
 
-	for _, result := range searchResponse.Query.Search {
-		// Check if the article is a disambiguation page
-		isDisambiguation := false
+	// Continue on a copy so that the caller's parameters are left as they were
+	continued := make(map[string]string, len(params))
 
-		categoryPage, ok := categoryResponse.Query.Pages[strconv.Itoa(result.Pageid)]
+	for key, value := range params {
+		continued[key] = value
+	}
 
-		if !ok {
-			continue
+	for next["excontinue"] != nil {
+		for key, value := range next {
+			continued[key] = continueValue(value)
 		}
 
-		if categoryPage.PageProps != nil {
-			isDisambiguation = categoryPage.PageProps.Disambiguation == ""
-		}
+		var continuation extractResponse
+
+		err = c.getJSON(ctx, continued, &continuation)
 
-		if isDisambiguation {
-			continue
+		if err != nil {
+			return nil, err
 		}
 
-		resultString += fmt.Sprintf("%d. %s\n", num, result.Title)
-		options[num] = result.Pageid
-		num++
+		for _, page := range continuation.Query.Pages {
+			for i := range extracts.Query.Pages {
+				if extracts.Query.Pages[i].Pageid == page.Pageid {
+					extracts.Query.Pages[i].Extract += page.Extract
+				}
+			}
+		}
 
-		if num > 10 {
+		// Stop if the API hands back the same offset rather than requesting it forever
+		if string(continuation.Continue["excontinue"]) == string(next["excontinue"]) {
 			break
 		}
-	}
 
-	if num < 1 {
-		writer.Write([]byte("No valid search results found\n"))
-		return options, nil
+		next = continuation.Continue
 	}
 
-	_, err = writer.Write([]byte(resultString))
+	return &extracts, nil
+}
 
-	if err != nil {
-		return make(map[int]int), err
+// continueValue returns a continuation parameter of a response as the query value to send back: strings as they
+// are, and numbers such as excontinue offsets in their JSON form.
+func continueValue(value json.RawMessage) string {
+	var text string
+
+	if json.Unmarshal(value, &text) == nil {
+		return text
 	}
 
-	return options, nil
+	return string(value)
 }
 
-func GetArticleSummary(pageId int, writer io.Writer) error {
-	explainUrl := fmt.Sprintf("https://en.wikipedia.org/w/api.php?format=json&action=query&prop=info|extracts&exlimit=max&explaintext&exintro&pageids=%d&inprop=url", pageId)
+// cleanExtract applies the client's optional post-processing to an extract.
+func (c *Client) cleanExtract(extract string) string {
+	extract = c.decodeText(extract)
 
-	httpClient := http.Client{}
+	if c.StripCitations {
+		extract = StripCitations(extract)
+	}
 
-	req, err := http.NewRequest("GET", explainUrl, nil)
+	if c.StripPronunciation {
+		extract = StripPronunciation(extract)
+	}
 
-	if err != nil {
-		return err
+	if c.NormalizeParagraphs {
+		extract = NormalizeParagraphs(extract)
 	}
 
-	resp, err := httpClient.Do(req)
+	return extract
+}
 
-	if err != nil {
-		return err
+// cleanIntro applies the client's optional post-processing to an intro extract.
+func (c *Client) cleanIntro(extract string) string {
+	extract = c.cleanExtract(extract)
+
+	if c.LeadOnly {
+		extract = CutAtFirstHeading(extract)
 	}
 
-	defer resp.Body.Close()
+	return extract
+}
 
-	responseBytes, err := io.ReadAll(resp.Body)
+// splitParagraphs splits an extract into its trimmed, non-empty paragraphs.
+func splitParagraphs(extract string) []string {
+	paragraphs := make([]string, 0)
 
-	if err != nil {
-		return err
+	for _, paragraph := range strings.Split(extract, "\n") {
+		paragraph = strings.TrimSpace(paragraph)
+
+		if paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
 	}
 
-	var extractResponse extractResponse
+	return paragraphs
+}
 
-	err = json.Unmarshal(responseBytes, &extractResponse)
+// summarize returns the first paragraph of the extract, or the first two if they fit, truncated to length characters
+// at a word boundary and marked with the ellipsis. A negative length disables truncation.
+func summarize(extract string, length int, ellipsis string) string {
+	// Split the text into paragraphs, skipping the blank lines between them
+	paragraphs := splitParagraphs(extract)
 
-	if err != nil {
-		return err
+	if len(paragraphs) == 0 {
+		return ""
+	}
+
+	// Get the first paragraph
+	summary := paragraphs[0]
+
+	// If there is a second paragraph and the first one fits, add it
+	if len(paragraphs) > 1 && (length < 0 || len([]rune(summary)) <= length) {
+		summary += "\n\n" + paragraphs[1]
 	}
 
-	// Get the page ID
-	var pgIdStr string
+	return truncate(summary, length, ellipsis)
+}
+
+// GetArticleSummary writes a summary of the article with the given page id to the given writer using DefaultClient.
+func GetArticleSummary(pageId int, writer io.Writer) error {
+	return DefaultClient.GetArticleSummary(context.Background(), pageId, writer)
+}
+
+// GetArticleSummary writes a summary of the article with the given page id to the given writer.
+func (c *Client) GetArticleSummary(ctx context.Context, pageId int, writer io.Writer) error {
+	_, err := c.WriteArticleSummary(ctx, pageId, writer)
+	return err
+}
+
+// GetSummaryForTitle writes a summary of the article with the given title to the given writer using DefaultClient.
+func GetSummaryForTitle(title string, writer io.Writer) error {
+	return DefaultClient.GetSummaryForTitle(context.Background(), title, writer)
+}
+
+// GetSummaryForTitle writes a summary of the article with the exact given title to the given writer, in the same
+// form as GetArticleSummary, without searching or prompting for a choice. The article is read from the client's
+// Backend, or from the action API when the client keeps redirects, which the REST API can't. When the title has
+// no article and the client has a FallbackLanguage, the article is looked up there instead, and the summary links
+// to that edition. It returns ErrPageNotFound when neither has the title.
+func (c *Client) GetSummaryForTitle(ctx context.Context, title string, writer io.Writer) error {
+	article, err := c.getTitleSummary(ctx, title)
+
+	if errors.Is(err, ErrPageNotFound) && c.FallbackLanguage != "" && c.FallbackLanguage != c.language() {
+		c = c.withLanguage(c.FallbackLanguage)
 
-	for key := range extractResponse.Query.Pages {
-		pgIdStr = key
-		break
+		article, err = c.getTitleSummary(ctx, title)
 	}
 
-	if extractResponse.Query.Pages[pgIdStr].Extract == "" {
-		return errors.New("no extract found")
+	if errors.Is(err, ErrPageNotFound) {
+		return fmt.Errorf("%w: %s", ErrPageNotFound, title)
 	}
 
-	articleUrl := extractResponse.Query.Pages[pgIdStr].FullURL
+	if err != nil {
+		return err
+	}
 
-	extract := extractResponse.Query.Pages[pgIdStr].Extract
+	_, err = io.WriteString(writer, c.FormatSummary(article))
 
-	// Get the first 500 characters of the extract or the first paragraph. Whichever is shorter
-	// Split the text into paragraphs
-	paragraphs := strings.Split(extract, "\n")
+	return err
+}
 
-	// Get the first paragraph
-	summary := paragraphs[0]
+// WriteArticleSummary writes a summary of the article with the given page id to the given writer using DefaultClient.
+// It returns the number of bytes written, following the io.WriterTo convention.
+func WriteArticleSummary(pageId int, writer io.Writer) (int64, error) {
+	return DefaultClient.WriteArticleSummary(context.Background(), pageId, writer)
+}
 
-	// If the first paragraph is longer than 1024 characters, truncate it
-	if len(summary) > 1024 {
-		summary = strings.TrimSpace(summary[:1024]) + "..."
-	} else {
-		// If there is a second paragraph, add it
-		if len(paragraphs) > 1 {
-			summary += "\n\n" + paragraphs[1]
+// WriteArticleSummary writes a summary of the article with the given page id to the given writer.
+// It returns the number of bytes written, following the io.WriterTo convention.
+func (c *Client) WriteArticleSummary(ctx context.Context, pageId int, writer io.Writer) (int64, error) {
+	article, err := c.GetArticleDetails(ctx, pageId)
 
-			if len(summary) > 1024 {
-				summary = strings.TrimSpace(summary[:1024]) + "..."
-			}
-		}
+	if err != nil {
+		return 0, err
 	}
 
-	// Add the find out more link
-	summary += fmt.Sprintf("\n\nFind out more: %s", articleUrl)
+	n, err := io.WriteString(writer, c.withInfoboxDigest(ctx, article.PageID, c.FormatSummary(article)))
 
-	io.WriteString(writer, summary)
+	return int64(n), err
+}
 
-	return nil
+// FormatSummary formats the article's preview for display, as written by WriteArticleSummary.
+func (c *Client) FormatSummary(article *Article) string {
+	if c.OmitMoreLink {
+		return article.Preview
+	}
+
+	label := c.MoreLinkLabel
+
+	if label == "" {
+		label = DefaultMoreLinkLabel
+	}
+
+	// Add the find out more link
+	return article.Preview + fmt.Sprintf("\n\n%s: %s", label, article.URL)
 }
 
-// GetWikiArticleSummary searches for the given topic on Wikipedia and writes a summary of the first search result to the given writer.
+// GetWikiArticleSummary searches for the given topic on Wikipedia and writes a summary of the chosen search result
+// to the given writer using DefaultClient.
 func GetWikiArticleSummary(topic string, writer io.Writer) error {
-	options, err := GetMatchingArticles(topic, writer)
+	return DefaultClient.GetWikiArticleSummary(context.Background(), topic, writer)
+}
+
+// GetWikiArticleSummary searches for the given topic on Wikipedia and writes a summary of the chosen search result
+// to the given writer.
+func (c *Client) GetWikiArticleSummary(ctx context.Context, topic string, writer io.Writer) error {
+	options, err := c.GetMatchingArticles(ctx, topic, writer)
 
 	if err != nil {
 		return err
@@ -323,7 +551,7 @@ func GetWikiArticleSummary(topic string, writer io.Writer) error {
 
 	// Get the user's choice
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Enter the number of the article you want to read: ")
+	fmt.Print(c.messages().ChoicePrompt)
 	choice, _ := reader.ReadString('\n')
 
 	choice = strings.TrimSpace(choice)
@@ -341,8 +569,14 @@ func GetWikiArticleSummary(topic string, writer io.Writer) error {
 		return errors.New("you must enter a valid number")
 	}
 
+	pageId, ok := options[choiceInt]
+
+	if !ok {
+		return fmt.Errorf("%w: %d is not between 1 and %d", ErrChoiceOutOfRange, choiceInt, len(options))
+	}
+
 	// Get the article summary
-	err = GetArticleSummary(options[choiceInt], writer)
+	err = c.GetArticleSummary(ctx, pageId, writer)
 
 	if err != nil {
 		return err
@@ -0,0 +1,142 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+// withStdin makes the input read from os.Stdin for the rest of the test.
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+
+	file, err := os.CreateTemp(t.TempDir(), "stdin")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = file.WriteString(input)
+
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdin := os.Stdin
+	os.Stdin = file
+
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		file.Close()
+	})
+}
+
+func TestGetWikiArticleSummaryChoiceOutOfRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		choice string
+	}{
+		{"zero", "0\n"},
+		{"past the last result", "3\n"},
+		{"negative", "-1\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var recorder queryRecorder
+
+			client := newTestClient(t, recorder.wrap(serveFixtures(t, [][2]string{
+				{"list=search", "search_machine_learning.json"},
+				{"prop=pageprops", "pageprops_machine_learning.json"},
+			})))
+
+			withStdin(t, test.choice)
+
+			err := client.GetWikiArticleSummary(context.Background(), "machine learning", io.Discard)
+
+			if !errors.Is(err, ErrChoiceOutOfRange) {
+				t.Fatalf("GetWikiArticleSummary() error = %v, want %v", err, ErrChoiceOutOfRange)
+			}
+
+			// Only the search and its disambiguation lookup are made, not a fetch of a bogus page
+			if len(recorder.queries) != 2 {
+				t.Errorf("made %d requests, want 2", len(recorder.queries))
+			}
+		})
+	}
+}
+
+func TestGetArticleDetailsDecodesEntities(t *testing.T) {
+	tests := []struct {
+		name        string
+		rawText     bool
+		wantTitle   string
+		wantExtract string
+	}{
+		{"decoded", false, "AT&T 'Death Star'", `The "Death Star" is the nickname of the globe logo AT&T introduced in 1983.`},
+		{"raw text", true, "AT&amp;T &#39;Death Star&#39;", "The &quot;Death Star&quot; is the nickname of the globe logo AT&amp;T introduced in 1983."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveFixture(t, "extracts_entities.json"))
+
+			client.RawText = test.rawText
+
+			article, err := client.GetArticleDetails(context.Background(), 17555269)
+
+			if err != nil {
+				t.Fatalf("GetArticleDetails() error = %v", err)
+			}
+
+			if article.Title != test.wantTitle {
+				t.Errorf("Title = %q, want %q", article.Title, test.wantTitle)
+			}
+
+			if article.Extract != test.wantExtract {
+				t.Errorf("Extract = %q, want %q", article.Extract, test.wantExtract)
+			}
+		})
+	}
+}
+
+func TestGetArticleSummaryNotFoundOrNoExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		pageId  int
+		wantErr error
+	}{
+		{"missing page", "extracts_missing.json", 999999999, ErrPageNotFound},
+		{"page without intro text", "extracts_empty.json", 61852290, ErrNoExtract},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveFixture(t, test.fixture))
+
+			err := client.GetArticleSummary(context.Background(), test.pageId, io.Discard)
+
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("GetArticleSummary() error = %v, want %v", err, test.wantErr)
+			}
+
+			// The two failures are told apart
+			other := ErrNoExtract
+
+			if test.wantErr == ErrNoExtract {
+				other = ErrPageNotFound
+			}
+
+			if errors.Is(err, other) {
+				t.Errorf("GetArticleSummary() error = %v, also matches %v", err, other)
+			}
+		})
+	}
+}
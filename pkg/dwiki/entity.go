@@ -0,0 +1,137 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Entity gathers what is known about the article a topic resolves to, as returned by GetEntity.
+type Entity struct {
+	Title       string `json:"title"`
+	PageID      int    `json:"pageid"`
+	URL         string `json:"url,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	WikidataID  string `json:"wikidataId,omitempty"`
+
+	// LanguageLinks maps language codes to the titles of the article's equivalents in those editions.
+	LanguageLinks map[string]string `json:"languageLinks,omitempty"`
+
+	// Aliases lists the titles of the articles redirecting to the article.
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// GetEntity resolves the topic to its best matching article and gathers its details using DefaultClient.
+func GetEntity(topic string) (*Entity, error) {
+	return DefaultClient.GetEntity(context.Background(), topic)
+}
+
+// GetEntity searches for the topic, takes the best candidate as scored by LookUpCandidates as its article, the
+// same one LookUp picks, and gathers the article's details in one call. After the search, four lookups run
+// concurrently: the article details for the url and summary, the classification for the description and Wikidata
+// item id, the language links, and the redirects for the aliases. It returns ErrPageNotFound when the search has
+// no results. A failed lookup doesn't stop the others: its fields are left empty and its error, prefixed with the
+// names of those fields, e.g. "aliases: ", is joined into the returned error alongside the entity, in the order
+// the lookups are listed above.
+func (c *Client) GetEntity(ctx context.Context, topic string) (*Entity, error) {
+	candidates, err := c.LookUpCandidates(ctx, topic)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPageNotFound, topic)
+	}
+
+	entity := &Entity{Title: candidates[0].Title, PageID: candidates[0].PageID}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	// Each lookup sets its own fields and reports its error under their names, in this order
+	lookups := []struct {
+		fields string
+		lookup func() error
+	}{
+		{"url, summary", func() error {
+			article, err := c.GetArticleDetails(ctx, entity.PageID)
+
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			entity.URL = article.URL
+			entity.Summary = article.Preview
+			return nil
+		}},
+		{"description, wikidata id", func() error {
+			classification, err := c.ClassifyArticle(ctx, entity.PageID)
+
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			entity.Description = classification.Description
+			entity.WikidataID = classification.WikidataID
+			return nil
+		}},
+		{"language links", func() error {
+			links, err := c.getLangLinks(ctx, entity.Title)
+
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			entity.LanguageLinks = links
+			return nil
+		}},
+		{"aliases", func() error {
+			aliased := []Article{{Title: entity.Title, PageID: entity.PageID}}
+
+			err := c.addAliases(ctx, aliased)
+
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			entity.Aliases = aliased[0].Aliases
+			return nil
+		}},
+	}
+
+	errs := make([]error, len(lookups))
+
+	for i, lookup := range lookups {
+		wg.Add(1)
+
+		go func(i int, fields string, lookup func() error) {
+			defer wg.Done()
+
+			err := lookup()
+
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", fields, err)
+			}
+		}(i, lookup.fields, lookup.lookup)
+	}
+
+	wg.Wait()
+
+	// errors.Join skips the lookups that succeeded
+	return entity, errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package dwiki
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetEntityErrorOrder(t *testing.T) {
+	search := readFixture(t, "search_machine_learning.json")
+
+	// Only the search succeeds, so every lookup fails
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("list") == "search" {
+			serveBody(search)(w, r)
+			return
+		}
+
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := client.GetEntity(context.Background(), "machine learning")
+
+		if err == nil {
+			t.Fatal("GetEntity() error = nil, want the lookups' errors")
+		}
+
+		fields := make([]string, 0)
+
+		for _, line := range strings.Split(err.Error(), "\n") {
+			name, _, _ := strings.Cut(line, ": ")
+			fields = append(fields, name)
+		}
+
+		want := "url, summary|description, wikidata id|language links|aliases"
+
+		if got := strings.Join(fields, "|"); got != want {
+			t.Fatalf("GetEntity() errors in the order %q, want %q", got, want)
+		}
+	}
+}
+
+func TestGetEntityPicksLookUpCandidate(t *testing.T) {
+	search := readFixture(t, "search_machine_learning.json")
+
+	// "Machine learning" ranks first in the search, but "Quantum machine learning" is the title that matches
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("list") == "search" {
+			serveBody(search)(w, r)
+			return
+		}
+
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	candidates, err := client.LookUpCandidates(context.Background(), "quantum machine learning")
+
+	if err != nil {
+		t.Fatalf("LookUpCandidates() error = %v", err)
+	}
+
+	entity, _ := client.GetEntity(context.Background(), "quantum machine learning")
+
+	if entity == nil {
+		t.Fatal("GetEntity() = nil, want an entity")
+	}
+
+	if entity.Title != "Quantum machine learning" || entity.PageID != candidates[0].PageID {
+		t.Errorf("GetEntity() = %q (%d), want %q (%d)", entity.Title, entity.PageID, candidates[0].Title, candidates[0].PageID)
+	}
+}
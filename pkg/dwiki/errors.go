@@ -0,0 +1,117 @@
+package dwiki
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrChoiceOutOfRange is returned when the chosen search result number isn't one of the listed results.
+var ErrChoiceOutOfRange = errors.New("choice out of range")
+
+// ErrPageNotFound is returned when the requested page doesn't exist.
+var ErrPageNotFound = errors.New("page not found")
+
+// ErrRevisionNotFound is returned when a requested revision doesn't exist or doesn't belong to the given page.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// ErrNoExtract is returned when a page exists but has no intro text to summarize.
+var ErrNoExtract = errors.New("no extract found")
+
+// ErrResponseTooLarge is returned when a response body is longer than the client's MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrNotDisambiguation is returned when a function that expects a disambiguation page is given another page.
+var ErrNotDisambiguation = errors.New("not a disambiguation page")
+
+// ErrNoImage is returned when an article has no lead image.
+var ErrNoImage = errors.New("article has no image")
+
+// ErrNoCache is returned by Prefetch when the client has no Cache to keep the fetched responses in.
+var ErrNoCache = errors.New("client has no cache")
+
+// APIError is an error reported by the API in the body of a response, e.g. for an invalid parameter.
+type APIError struct {
+	Code string
+	Info string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %s: %s", e.Code, e.Info)
+}
+
+// APIWarnings holds the warnings reported by the API in the body of a response, keyed by the module that
+// raised them. It is only returned as an error when the client's FailOnWarnings is set.
+type APIWarnings map[string]string
+
+func (w APIWarnings) Error() string {
+	modules := make([]string, 0, len(w))
+
+	for module := range w {
+		modules = append(modules, module)
+	}
+
+	sort.Strings(modules)
+
+	messages := make([]string, 0, len(modules))
+
+	for _, module := range modules {
+		messages = append(messages, fmt.Sprintf("%s: %s", module, w[module]))
+	}
+
+	return "api warnings: " + strings.Join(messages, "; ")
+}
+
+// apiEnvelope holds the error and warnings the action API can report in any response. Every action API response
+// type embeds it, so that a response decoded straight from the network can be checked afterwards.
+type apiEnvelope struct {
+	Error *struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+	Warnings map[string]struct {
+		Text string `json:"warnings"`
+	} `json:"warnings"`
+}
+
+// enveloped is implemented by the response types embedding apiEnvelope.
+type enveloped interface {
+	envelope() *apiEnvelope
+}
+
+func (e *apiEnvelope) envelope() *apiEnvelope {
+	return e
+}
+
+// check returns the error or, if failOnWarnings is set, the warnings reported in the envelope.
+func (e *apiEnvelope) check(failOnWarnings bool) error {
+	if e.Error != nil {
+		return &APIError{Code: e.Error.Code, Info: e.Error.Info}
+	}
+
+	if failOnWarnings && len(e.Warnings) > 0 {
+		warnings := make(APIWarnings)
+
+		for module, warning := range e.Warnings {
+			warnings[module] = warning.Text
+		}
+
+		return warnings
+	}
+
+	return nil
+}
+
+// checkEnvelope returns the error or, if failOnWarnings is set, the warnings reported in an API response body.
+// Bodies that aren't a JSON object are left for the caller's decoding to report.
+func checkEnvelope(body []byte, failOnWarnings bool) error {
+	var envelope apiEnvelope
+
+	if json.Unmarshal(body, &envelope) != nil {
+		return nil
+	}
+
+	return envelope.check(failOnWarnings)
+}
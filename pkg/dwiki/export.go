@@ -0,0 +1,125 @@
+package dwiki
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// GetArticleJSON returns the article with the given page id as JSON using DefaultClient.
+func GetArticleJSON(pageId int) ([]byte, error) {
+	return DefaultClient.GetArticleJSON(context.Background(), pageId)
+}
+
+// GetArticleJSON returns the article with the given page id marshaled as a JSON Article, with its title,
+// page id, url, word count, extract, preview and categories filled in.
+func (c *Client) GetArticleJSON(ctx context.Context, pageId int) ([]byte, error) {
+	article, err := c.GetArticleDetails(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	article.WordCount, err = c.getWordCount(ctx, article.Title, article.PageID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	article.Categories, err = c.getCategories(ctx, article.PageID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(article)
+}
+
+// getWordCount looks up the word count of an article, which only the search API reports, by searching for its
+// exact title. It returns zero if the article isn't among the matches.
+func (c *Client) getWordCount(ctx context.Context, title string, pageId int) (int, error) {
+	params := searchParams(`"`+title+`"`, 10)
+
+	params["srprop"] = "wordcount"
+
+	var searchResponse searchResponse
+
+	err := c.getJSON(ctx, params, &searchResponse)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, result := range searchResponse.Query.Search {
+		if result.Pageid == pageId {
+			return result.Wordcount, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// WriteNDJSON writes the articles to w as newline-delimited JSON, one Article object per line, so that consumers
+// can process them as a stream. Each line is a complete JSON document.
+func WriteNDJSON(articles []Article, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+
+	for _, article := range articles {
+		err := encoder.Encode(article)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteResultsCSV writes the articles to w as CSV using DefaultClient.
+func WriteResultsCSV(articles []Article, w io.Writer) error {
+	return DefaultClient.WriteResultsCSV(articles, w)
+}
+
+// WriteResultsCSV writes the articles to w as CSV, for use in a spreadsheet, with a header row followed by one row
+// per article with its title, page id, word count, url and description. Fields containing commas, quotes or line
+// breaks are quoted. Articles without a URL, such as search results, get the url of the article on the client's
+// wiki. The description is only filled in for articles that have one, e.g. searches made WithDescriptions.
+func (c *Client) WriteResultsCSV(articles []Article, w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"title", "pageid", "wordcount", "url", "description"})
+
+	if err != nil {
+		return err
+	}
+
+	for _, article := range articles {
+		articleURL := article.URL
+
+		if articleURL == "" {
+			articleURL, err = c.articleURL(article.Title)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		err = writer.Write([]string{
+			article.Title,
+			strconv.Itoa(article.PageID),
+			strconv.Itoa(article.WordCount),
+			articleURL,
+			article.Description,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
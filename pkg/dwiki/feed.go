@@ -0,0 +1,239 @@
+package dwiki
+
+import (
+	"context"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// feedLinkPattern matches a link to an article in the HTML of a feed item or a parsed page, capturing the title as
+// a relative path, e.g. "./Example_article" or "/wiki/Example_article".
+var feedLinkPattern = regexp.MustCompile(`<a [^>]*href="(?:\./|/wiki/)([^"#?]+)"`)
+
+// dykItemPattern matches an item of the "Did you know" list on the main page, capturing its HTML.
+var dykItemPattern = regexp.MustCompile(`(?s)<li[^>]*>(.*?)</li>`)
+
+// featuredFeedPath returns the REST API path of the featured feed for the given date, in UTC.
+func featuredFeedPath(date time.Time) string {
+	return "/feed/featured/" + date.UTC().Format("2006/01/02")
+}
+
+// NewsStory is a current event featured in the "In the news" section of the main page, along with the articles
+// it links to.
+type NewsStory struct {
+	Story    string    `json:"story"`
+	Articles []Article `json:"articles"`
+}
+
+// feedSummary is a page summary in the REST format, as embedded in feeds and served by /page/summary.
+type feedSummary struct {
+	PageID int    `json:"pageid"`
+	Title  string `json:"title"`
+	Titles struct {
+		Normalized string `json:"normalized"`
+	} `json:"titles"`
+	Description string `json:"description"`
+	Extract     string `json:"extract"`
+	Thumbnail   *struct {
+		Source string `json:"source"`
+	} `json:"thumbnail"`
+	ContentURLs struct {
+		Desktop struct {
+			Page string `json:"page"`
+		} `json:"desktop"`
+		Mobile struct {
+			Page string `json:"page"`
+		} `json:"mobile"`
+	} `json:"content_urls"`
+}
+
+// Fact is a "Did you know" item of the main page, along with the titles of the articles it links to.
+type Fact struct {
+	Text   string   `json:"text"`
+	Titles []string `json:"titles"`
+}
+
+type featuredFeedResponse struct {
+	News []struct {
+		Story string        `json:"story"`
+		Links []feedSummary `json:"links"`
+	} `json:"news"`
+	DYK []struct {
+		HTML string `json:"html"`
+		Text string `json:"text"`
+	} `json:"dyk"`
+}
+
+// GetInTheNews returns the stories currently featured in the "In the news" section using DefaultClient.
+func GetInTheNews() ([]NewsStory, error) {
+	return DefaultClient.GetInTheNews(context.Background())
+}
+
+// GetInTheNews returns the stories currently featured in the "In the news" section, as GetInTheNewsOn does for
+// today's UTC date.
+func (c *Client) GetInTheNews(ctx context.Context) ([]NewsStory, error) {
+	return c.GetInTheNewsOn(ctx, time.Now())
+}
+
+// GetInTheNewsOn returns the stories featured in the "In the news" section on the given date using DefaultClient.
+func GetInTheNewsOn(date time.Time) ([]NewsStory, error) {
+	return DefaultClient.GetInTheNewsOn(context.Background(), date)
+}
+
+// GetInTheNewsOn returns the stories featured in the "In the news" section on the given date, in UTC, read from
+// the REST /feed/featured endpoint for that date. The articles of each story carry their title, page id, url and
+// intro extract. The stories change daily and not every language edition publishes them, so an empty result is
+// not an error. The feed is updated during the day, so it is never served from the client's Cache.
+func (c *Client) GetInTheNewsOn(ctx context.Context, date time.Time) ([]NewsStory, error) {
+	var featuredFeedResponse featuredFeedResponse
+
+	path := featuredFeedPath(date)
+
+	err := c.uncached().getRESTJSON(ctx, path, &featuredFeedResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stories := make([]NewsStory, 0, len(featuredFeedResponse.News))
+
+	for _, news := range featuredFeedResponse.News {
+		story := NewsStory{
+			Story:    stripTags(news.Story),
+			Articles: make([]Article, 0, len(news.Links)),
+		}
+
+		for _, link := range news.Links {
+			story.Articles = append(story.Articles, c.feedArticle(link))
+		}
+
+		stories = append(stories, story)
+	}
+
+	return stories, nil
+}
+
+// GetDidYouKnow returns the facts currently featured in the "Did you know" section using DefaultClient.
+func GetDidYouKnow() ([]Fact, error) {
+	return DefaultClient.GetDidYouKnow(context.Background())
+}
+
+// GetDidYouKnow returns the facts currently featured in the "Did you know" section, read from the REST
+// /feed/featured endpoint for today's UTC date. Each fact holds its plain text, e.g. "... that the first ...?",
+// and the titles of the articles it links to, in the order they appear. Most editions leave the section out of
+// the feed, so when it has none the facts are read from the section of the wiki's main page with the id
+// "mp-dyk", as on the English Wikipedia, at the cost of two extra requests. An edition without either has no
+// facts, which is not an error. The feed and the main page are updated during the day, so they are never served
+// from the client's Cache.
+func (c *Client) GetDidYouKnow(ctx context.Context) ([]Fact, error) {
+	var featuredFeedResponse featuredFeedResponse
+
+	path := featuredFeedPath(time.Now())
+
+	err := c.uncached().getRESTJSON(ctx, path, &featuredFeedResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(featuredFeedResponse.DYK) == 0 {
+		return c.getMainPageFacts(ctx)
+	}
+
+	facts := make([]Fact, 0, len(featuredFeedResponse.DYK))
+
+	for _, item := range featuredFeedResponse.DYK {
+		facts = append(facts, dykFact(item.Text, item.HTML))
+	}
+
+	return facts, nil
+}
+
+// getMainPageFacts returns the facts of the "Did you know" section of the wiki's main page, the list in the
+// element with the id "mp-dyk", or none when the main page has no such section.
+func (c *Client) getMainPageFacts(ctx context.Context) ([]Fact, error) {
+	title, err := c.getMainPageTitle(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+
+	params["action"] = "parse"
+	params["page"] = title
+	params["prop"] = "text"
+	params["format"] = "json"
+
+	var parseResponse parseResponse
+
+	err = c.uncached().getJSON(ctx, params, &parseResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	facts := make([]Fact, 0)
+
+	_, section, ok := strings.Cut(parseResponse.Parse.Text, `id="mp-dyk"`)
+
+	if !ok {
+		return facts, nil
+	}
+
+	// The facts are the items of the section's first list, which the archive and nomination links follow
+	_, list, ok := strings.Cut(section, "<ul")
+
+	if !ok {
+		return facts, nil
+	}
+
+	list, _, _ = strings.Cut(list, "</ul>")
+
+	for _, match := range dykItemPattern.FindAllStringSubmatch(list, -1) {
+		facts = append(facts, dykFact("", match[1]))
+	}
+
+	return facts, nil
+}
+
+// dykFact returns the fact with the given plain text and HTML, taking the text from the HTML when it is empty and
+// the titles from the HTML's article links.
+func dykFact(text, itemHTML string) Fact {
+	fact := Fact{Text: strings.TrimSpace(text), Titles: make([]string, 0)}
+
+	if fact.Text == "" {
+		fact.Text = stripTags(itemHTML)
+	}
+
+	for _, match := range feedLinkPattern.FindAllStringSubmatch(itemHTML, -1) {
+		title := html.UnescapeString(match[1])
+
+		if unescaped, err := url.PathUnescape(title); err == nil {
+			title = unescaped
+		}
+
+		fact.Titles = append(fact.Titles, strings.ReplaceAll(title, "_", " "))
+	}
+
+	return fact
+}
+
+// feedArticle converts a page summary from a feed into an Article.
+func (c *Client) feedArticle(summary feedSummary) Article {
+	title := summary.Titles.Normalized
+
+	if title == "" {
+		title = summary.Title
+	}
+
+	return Article{
+		Title:   c.decodeText(title),
+		PageID:  summary.PageID,
+		URL:     summary.ContentURLs.Desktop.Page,
+		Extract: c.cleanIntro(summary.Extract),
+	}
+}
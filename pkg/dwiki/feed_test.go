@@ -0,0 +1,73 @@
+package dwiki
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetDidYouKnow(t *testing.T) {
+	tests := []struct {
+		name      string
+		feed      string
+		mainPage  string
+		wantFacts []Fact
+	}{
+		{
+			"from the feed",
+			"feed_featured.json",
+			"",
+			[]Fact{{
+				Text:   "... that the Go gopher was drawn by Renée French?",
+				Titles: []string{"Gopher (mascot)", "Renée French"},
+			}},
+		},
+		{
+			"from the main page",
+			"feed_featured_no_dyk.json",
+			"parse_main_page.json",
+			[]Fact{
+				{
+					Text:   "... that the Go gopher (pictured) was drawn by Renée French?",
+					Titles: []string{"Gopher (mascot)", "Renée French"},
+				},
+				{Text: "... that AT&T introduced its globe logo in 1983?", Titles: []string{"AT&T"}},
+			},
+		},
+		{"neither", "feed_featured_no_dyk.json", "parse_main_page_no_dyk.json", []Fact{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			feed := serveFixture(t, test.feed)
+			routes := [][2]string{{"meta=siteinfo", "siteinfo.json"}}
+
+			if test.mainPage != "" {
+				routes = append(routes, [2]string{"action=parse", test.mainPage})
+			}
+
+			api := serveFixtures(t, routes)
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if strings.Contains(r.URL.Path, "/feed/featured/") {
+					feed(w, r)
+					return
+				}
+
+				api(w, r)
+			})
+
+			facts, err := client.GetDidYouKnow(context.Background())
+
+			if err != nil {
+				t.Fatalf("GetDidYouKnow() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(facts, test.wantFacts) {
+				t.Errorf("GetDidYouKnow() = %#v, want %#v", facts, test.wantFacts)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package dwiki
+
+import (
+	"context"
+	"sort"
+	"strconv"
+)
+
+// fileNamespace is the namespace of media file pages, whose titles start with "File:".
+const fileNamespace = 6
+
+// FileResult is a media file found by SearchFiles.
+type FileResult struct {
+	// Title is the file page title, e.g. "File:Example.jpg".
+	Title string `json:"title"`
+
+	// URL is the address of the file itself.
+	URL string `json:"url"`
+
+	// DescriptionURL is the address of the file's description page.
+	DescriptionURL string `json:"descriptionurl"`
+
+	Mime   string `json:"mime,omitempty"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+type fileSearchResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Pageid    int    `json:"pageid"`
+			Ns        int    `json:"ns"`
+			Title     string `json:"title"`
+			Index     int    `json:"index"`
+			ImageInfo []struct {
+				URL            string `json:"url"`
+				DescriptionURL string `json:"descriptionurl"`
+				Mime           string `json:"mime"`
+				Width          int    `json:"width"`
+				Height         int    `json:"height"`
+			} `json:"imageinfo"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// SearchFiles searches for media files matching the given topic using DefaultClient.
+func SearchFiles(topic string, limit int) ([]FileResult, error) {
+	return DefaultClient.SearchFiles(context.Background(), topic, limit)
+}
+
+// SearchFiles searches the File namespace for up to limit media files matching the given topic, most relevant
+// first, and returns them with their urls. The search and the file details are fetched in one request by
+// using the search as a generator for imageinfo. If limit is zero or less, the client's SearchLimit is used.
+func (c *Client) SearchFiles(ctx context.Context, topic string, limit int) ([]FileResult, error) {
+	if limit <= 0 {
+		limit = c.searchLimit()
+	}
+
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["generator"] = "search"
+	params["gsrsearch"] = topic
+	params["gsrnamespace"] = strconv.Itoa(fileNamespace)
+	params["gsrlimit"] = strconv.Itoa(limit)
+	params["prop"] = "imageinfo"
+	params["iiprop"] = "url|mime|size"
+	params["format"] = "json"
+
+	var fileSearchResponse fileSearchResponse
+
+	err := c.getJSON(ctx, params, &fileSearchResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pages := fileSearchResponse.Query.Pages
+
+	// Generated pages come back in page id order, with their search rank in index
+	sort.SliceStable(pages, func(i, j int) bool {
+		return pages[i].Index < pages[j].Index
+	})
+
+	files := make([]FileResult, 0, len(pages))
+
+	for _, page := range pages {
+		if len(page.ImageInfo) == 0 {
+			continue
+		}
+
+		info := page.ImageInfo[0]
+
+		files = append(files, FileResult{
+			Title:          c.decodeText(page.Title),
+			URL:            info.URL,
+			DescriptionURL: info.DescriptionURL,
+			Mime:           info.Mime,
+			Width:          info.Width,
+			Height:         info.Height,
+		})
+	}
+
+	return files, nil
+}
@@ -0,0 +1,85 @@
+package dwiki
+
+import "context"
+
+// flight is a request in flight shared by every caller asking for its url at the same time.
+type flight struct {
+	// done is closed once the response has been read into body, status and err.
+	done   chan struct{}
+	body   []byte
+	status int
+	err    error
+
+	// waiters counts the callers waiting for the response. The request is cancelled when all of them give up.
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// fetchShared returns the body and status code of a GET request to the url, joining an identical request already
+// in flight rather than sending another one. The request runs on its own context, carrying the values of the
+// first caller's, so that one caller giving up doesn't fail it for the others; it is only cancelled once every
+// caller waiting for it has given up, and each caller returns its own context's error as soon as it is done.
+func (c *Client) fetchShared(ctx context.Context, requestURL string) ([]byte, int, error) {
+	state := c.state()
+
+	key := c.cacheKey(requestURL)
+
+	state.mu.Lock()
+
+	f, ok := state.flights[key]
+
+	if ok {
+		state.stats.sharedRequests.Add(1)
+	} else {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+
+		if state.flights == nil {
+			state.flights = make(map[string]*flight)
+		}
+
+		state.flights[key] = f
+
+		go func() {
+			defer cancel()
+
+			f.body, f.status, f.err = c.fetchBody(flightCtx, requestURL)
+
+			state.mu.Lock()
+
+			if state.flights[key] == f {
+				delete(state.flights, key)
+			}
+
+			state.mu.Unlock()
+
+			close(f.done)
+		}()
+	}
+
+	f.waiters++
+
+	state.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, f.status, f.err
+	case <-ctx.Done():
+		state.mu.Lock()
+		defer state.mu.Unlock()
+
+		f.waiters--
+
+		// Later callers start a new request rather than joining the cancelled one
+		if f.waiters == 0 {
+			f.cancel()
+
+			if state.flights[key] == f {
+				delete(state.flights, key)
+			}
+		}
+
+		return nil, 0, ctx.Err()
+	}
+}
@@ -0,0 +1,67 @@
+package dwiki
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// identifierPatterns match the external identifiers cited in wikitext, keyed by identifier type. Each pattern
+// captures the identifier itself, whether it is given as a citation template parameter, e.g. "|isbn=...", or
+// inline, e.g. "ISBN 978-0-00-000000-0".
+var identifierPatterns = map[string]*regexp.Regexp{
+	"isbn":  regexp.MustCompile(`(?i)\bisbn(?:13)?\s*[=:]?\s*((?:97[89][- ]?)?\d[\d -]{7,15}[\dX])\b`),
+	"doi":   regexp.MustCompile(`(?i)\b(10\.\d{4,9}/[^\s|}\]<>"]+)`),
+	"issn":  regexp.MustCompile(`(?i)\be?issn\s*[=:]?\s*(\d{4}-\d{3}[\dX])\b`),
+	"pmid":  regexp.MustCompile(`(?i)\bpmid\s*[=:]?\s*(\d+)\b`),
+	"pmc":   regexp.MustCompile(`(?i)\bpmc\s*=\s*(?:PMC)?(\d+)\b`),
+	"oclc":  regexp.MustCompile(`(?i)\boclc\s*[=:]?\s*(\d+)\b`),
+	"arxiv": regexp.MustCompile(`(?i)\barxiv\s*[=:]\s*([\w.\-/]+\d)`),
+}
+
+// GetExternalIDs returns the external identifiers cited by the article with the given page id using DefaultClient.
+func GetExternalIDs(pageId int) (map[string][]string, error) {
+	return DefaultClient.GetExternalIDs(context.Background(), pageId)
+}
+
+// GetExternalIDs returns the external identifiers, such as ISBNs and DOIs, cited in the wikitext of the article
+// with the given page id. The result is keyed by identifier type: "isbn", "doi", "issn", "pmid", "pmc", "oclc"
+// and "arxiv". Each list holds the distinct identifiers in the order they first appear; types the article
+// doesn't cite are omitted. The identifiers are matched with patterns rather than validated, so an occasional
+// malformed one may be included.
+func (c *Client) GetExternalIDs(ctx context.Context, pageId int) (map[string][]string, error) {
+	wikitext, err := c.GetWikitext(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return externalIDs(wikitext), nil
+}
+
+// externalIDs extracts the identifiers matched by identifierPatterns from wikitext.
+func externalIDs(wikitext string) map[string][]string {
+	ids := make(map[string][]string)
+
+	for kind, pattern := range identifierPatterns {
+		seen := make(map[string]bool)
+
+		for _, match := range pattern.FindAllStringSubmatch(wikitext, -1) {
+			id := strings.TrimSpace(match[1])
+
+			// ISBNs are written with varying separators, so they are compared without them
+			if kind == "isbn" {
+				id = strings.NewReplacer("-", "", " ", "").Replace(id)
+			}
+
+			if id == "" || seen[id] {
+				continue
+			}
+
+			seen[id] = true
+			ids[kind] = append(ids[kind], id)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,388 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// htmlHeadingPattern matches the opening tag of a section heading in parsed article HTML.
+	htmlHeadingPattern = regexp.MustCompile(`<h[2-6][\s>]`)
+
+	// fileLinkPattern matches the link around an embedded image in parsed article HTML, capturing the
+	// file's page name, e.g. "File:Example.jpg". Older parser output marks the link with class="image".
+	fileLinkPattern = regexp.MustCompile(`<a href="/wiki/([^"#?]+)"[^>]*class="(?:mw-file-description|image)"`)
+)
+
+type pageImagesResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Pageid    int    `json:"pageid"`
+			Title     string `json:"title"`
+			Missing   bool   `json:"missing"`
+			PageImage string `json:"pageimage"`
+			Thumbnail *struct {
+				Source string `json:"source"`
+				Width  int    `json:"width"`
+				Height int    `json:"height"`
+			} `json:"thumbnail"`
+			Original *struct {
+				Source string `json:"source"`
+				Width  int    `json:"width"`
+				Height int    `json:"height"`
+			} `json:"original"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+type imageInfoResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Title     string `json:"title"`
+			ImageInfo []struct {
+				URL         string `json:"url"`
+				ExtMetadata map[string]struct {
+					Value string `json:"value"`
+				} `json:"extmetadata"`
+			} `json:"imageinfo"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// getLeadImageName returns the file name of the article's lead image, or an empty string if it has none.
+func (c *Client) getLeadImageName(ctx context.Context, pageId int) (string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "pageimages"
+	params["piprop"] = "name"
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	var pageImagesResponse pageImagesResponse
+
+	err := c.getJSON(ctx, params, &pageImagesResponse)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, page := range pageImagesResponse.Query.Pages {
+		if page.Pageid == pageId && !page.Missing {
+			return page.PageImage, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+}
+
+// getImageMetadata returns the extended metadata of the given file, such as its description and license,
+// with the HTML of each value reduced to plain text.
+func (c *Client) getImageMetadata(ctx context.Context, fileName string) (string, map[string]string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "imageinfo"
+	params["iiprop"] = "url|extmetadata"
+	params["format"] = "json"
+	params["titles"] = "File:" + fileName
+
+	var imageInfoResponse imageInfoResponse
+
+	err := c.getJSON(ctx, params, &imageInfoResponse)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	metadata := make(map[string]string)
+
+	for _, page := range imageInfoResponse.Query.Pages {
+		if len(page.ImageInfo) == 0 {
+			continue
+		}
+
+		for key, value := range page.ImageInfo[0].ExtMetadata {
+			metadata[key] = stripTags(value.Value)
+		}
+
+		return page.ImageInfo[0].URL, metadata, nil
+	}
+
+	return "", metadata, nil
+}
+
+// GetLeadImageCaption returns the description of the lead image of the article with the given page id using
+// DefaultClient.
+func GetLeadImageCaption(pageId int) (string, error) {
+	return DefaultClient.GetLeadImageCaption(context.Background(), pageId)
+}
+
+// GetLeadImageCaption returns the description of the lead image of the article with the given page id, taken
+// from the file's description page, as plain text. It returns an empty string when the article has no lead
+// image or the image has no description.
+func (c *Client) GetLeadImageCaption(ctx context.Context, pageId int) (string, error) {
+	fileName, err := c.getLeadImageName(ctx, pageId)
+
+	if err != nil || fileName == "" {
+		return "", err
+	}
+
+	_, metadata, err := c.getImageMetadata(ctx, fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	return metadata["ImageDescription"], nil
+}
+
+// ImageInfo is a file with the details needed to credit it when reusing it, as returned by GetLeadImageWithLicense.
+type ImageInfo struct {
+	// FileName is the name of the file without the "File:" prefix, e.g. "Example.jpg".
+	FileName string `json:"fileName"`
+
+	// URL is the address of the full-resolution file.
+	URL string `json:"url"`
+
+	// License is the short name of the file's license, e.g. "CC BY-SA 4.0", and LicenseURL links to its terms.
+	// Both are empty if the file's description page doesn't state them.
+	License    string `json:"license,omitempty"`
+	LicenseURL string `json:"licenseUrl,omitempty"`
+
+	// Artist is the author of the file and Credit its source, both as plain text.
+	Artist string `json:"artist,omitempty"`
+	Credit string `json:"credit,omitempty"`
+
+	// AttributionRequired reports whether the license requires crediting the author, with Attribution as the
+	// text to use when the file's page gives one.
+	AttributionRequired bool   `json:"attributionRequired"`
+	Attribution         string `json:"attribution,omitempty"`
+}
+
+// GetLeadImageWithLicense returns the lead image of the article with the given page id and its license using
+// DefaultClient.
+func GetLeadImageWithLicense(pageId int) (*ImageInfo, error) {
+	return DefaultClient.GetLeadImageWithLicense(context.Background(), pageId)
+}
+
+// GetLeadImageWithLicense returns the lead image of the article with the given page id along with its license and
+// author, taken from the file's description page, so that it can be reused with proper credit. It returns
+// ErrNoImage when the article has no lead image.
+func (c *Client) GetLeadImageWithLicense(ctx context.Context, pageId int) (*ImageInfo, error) {
+	fileName, err := c.getLeadImageName(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fileName == "" {
+		return nil, ErrNoImage
+	}
+
+	fileURL, metadata, err := c.getImageMetadata(ctx, fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageInfo{
+		FileName:            fileName,
+		URL:                 fileURL,
+		License:             metadata["LicenseShortName"],
+		LicenseURL:          metadata["LicenseUrl"],
+		Artist:              metadata["Artist"],
+		Credit:              metadata["Credit"],
+		AttributionRequired: metadata["AttributionRequired"] == "true",
+		Attribution:         metadata["Attribution"],
+	}, nil
+}
+
+// leadThumbnailWidth is the width in pixels of the lead image thumbnails returned by GetLeadImageURLs and
+// GetReaderView.
+const leadThumbnailWidth = 320
+
+// GetLeadImageURLs returns the thumbnail and original urls of the lead image of the article with the given page id
+// using DefaultClient.
+func GetLeadImageURLs(pageId int) (thumb, original string, err error) {
+	return DefaultClient.GetLeadImageURLs(context.Background(), pageId)
+}
+
+// GetLeadImageURLs returns the urls of the lead image of the article with the given page id, both as a thumbnail
+// scaled to 320 pixels wide and as the original, full-resolution file, in a single request. Both are empty when
+// the article has no lead image.
+func (c *Client) GetLeadImageURLs(ctx context.Context, pageId int) (thumb, original string, err error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "pageimages"
+	params["piprop"] = "thumbnail|original"
+	params["pithumbsize"] = strconv.Itoa(leadThumbnailWidth)
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	var pageImagesResponse pageImagesResponse
+
+	err = c.getJSON(ctx, params, &pageImagesResponse)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, page := range pageImagesResponse.Query.Pages {
+		if page.Pageid != pageId || page.Missing {
+			continue
+		}
+
+		if page.Thumbnail != nil {
+			thumb = page.Thumbnail.Source
+		}
+
+		if page.Original != nil {
+			original = page.Original.Source
+		}
+
+		return thumb, original, nil
+	}
+
+	return "", "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+}
+
+// DownloadThumbnail fetches the lead image thumbnail of the article with the given page id using DefaultClient.
+func DownloadThumbnail(pageId int, size int) ([]byte, string, error) {
+	return DefaultClient.DownloadThumbnail(context.Background(), pageId, size)
+}
+
+// DownloadThumbnail fetches the thumbnail of the article's lead image scaled to the given width in pixels, returning
+// the image bytes and their content type, e.g. "image/jpeg". It returns ErrNoImage when the article has no
+// lead image. The download goes through the client's http client, rate limit and retries like any request.
+func (c *Client) DownloadThumbnail(ctx context.Context, pageId int, size int) ([]byte, string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "pageimages"
+	params["piprop"] = "thumbnail"
+	params["pithumbsize"] = strconv.Itoa(size)
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	var pageImagesResponse pageImagesResponse
+
+	err := c.getJSON(ctx, params, &pageImagesResponse)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	thumbnailURL := ""
+
+	for _, page := range pageImagesResponse.Query.Pages {
+		if page.Pageid == pageId && page.Thumbnail != nil {
+			thumbnailURL = page.Thumbnail.Source
+		}
+	}
+
+	if thumbnailURL == "" {
+		return nil, "", ErrNoImage
+	}
+
+	resp, release, err := c.do(ctx, thumbnailURL)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer release()
+
+	c.limitBody(resp)
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.state().stats.httpErrors.Add(1)
+		return nil, "", fmt.Errorf("image download error: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	image, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		c.state().stats.networkErrors.Add(1)
+		return nil, "", err
+	}
+
+	return image, resp.Header.Get("Content-Type"), nil
+}
+
+// GetSectionImages returns the images of the article with the given page id grouped by section using DefaultClient.
+func GetSectionImages(pageId int) (map[int][]string, error) {
+	return DefaultClient.GetSectionImages(context.Background(), pageId)
+}
+
+// GetSectionImages returns the file titles of the images embedded in the article with the given page id, e.g.
+// "File:Example.jpg", keyed by the index of the section they appear in, in page order. The lead, including the
+// infobox, is section 0 and the sections that follow are numbered from 1 in the order their headings appear,
+// subsections included. Sections without images are omitted, and an image repeated within a section is
+// listed once. It makes a single request for the parsed article HTML.
+func (c *Client) GetSectionImages(ctx context.Context, pageId int) (map[int][]string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "parse"
+	params["pageid"] = strconv.Itoa(pageId)
+	params["prop"] = "text"
+	params["disabletoc"] = "1"
+	params["disableeditsection"] = "1"
+	params["format"] = "json"
+
+	var parseResponse parseResponse
+
+	err := c.getJSON(ctx, params, &parseResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sectionImages(parseResponse.Parse.Text), nil
+}
+
+// sectionImages finds the images linked in the article HTML and groups them by the number of headings before them.
+func sectionImages(text string) map[int][]string {
+	images := make(map[int][]string)
+
+	headings := htmlHeadingPattern.FindAllStringIndex(text, -1)
+
+	for _, match := range fileLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		section := 0
+
+		for section < len(headings) && headings[section][0] < match[0] {
+			section++
+		}
+
+		name := html.UnescapeString(text[match[2]:match[3]])
+
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			name = unescaped
+		}
+
+		name = strings.ReplaceAll(name, "_", " ")
+
+		if !slices.Contains(images[section], name) {
+			images[section] = append(images[section], name)
+		}
+	}
+
+	return images
+}
@@ -0,0 +1,391 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type infoResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Normalized []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"normalized"`
+		Redirects []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"redirects"`
+		Pages []infoPage `json:"pages"`
+	} `json:"query"`
+}
+
+type infoPage struct {
+	Pageid  int    `json:"pageid"`
+	Ns      int    `json:"ns"`
+	Title   string `json:"title"`
+	Missing bool   `json:"missing"`
+	Invalid bool   `json:"invalid"`
+	FullURL string `json:"fullurl"`
+	Length  int    `json:"length"`
+	Talkid  int    `json:"talkid"`
+
+	Protection []ProtectionInfo `json:"protection"`
+}
+
+// ProtectionInfo describes a restriction on changing a page.
+type ProtectionInfo struct {
+	// Type is the action restricted, e.g. "edit" or "move".
+	Type string `json:"type"`
+
+	// Level is the user group allowed to perform it, e.g. "autoconfirmed" or "sysop".
+	Level string `json:"level"`
+
+	// Expiry is when the protection ends, as an ISO 8601 timestamp, or "infinity" if it is indefinite.
+	Expiry string `json:"expiry"`
+}
+
+// exists reports whether the page is present, i.e. neither missing nor an invalid title.
+func (p infoPage) exists() bool {
+	return !p.Missing && !p.Invalid
+}
+
+// ArticleExists reports whether an article with the given title exists using DefaultClient.
+func ArticleExists(title string) (bool, error) {
+	return DefaultClient.ArticleExists(context.Background(), title)
+}
+
+// ArticleExists reports whether an article with the given title exists, following redirects so that a title
+// such as "UK" that redirects to an existing article counts as existing. A missing article is not an error;
+// the error is only set when the request fails. With the client's KeepRedirects set, the redirect itself counts.
+func (c *Client) ArticleExists(ctx context.Context, title string) (bool, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["titles"] = c.normalizeTitle(title)
+	c.followRedirects(params)
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err := c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if page.exists() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// FindRedirectTarget reports whether the given title is a redirect and returns its target using DefaultClient.
+func FindRedirectTarget(title string) (string, bool, error) {
+	return DefaultClient.FindRedirectTarget(context.Background(), title)
+}
+
+// FindRedirectTarget reports whether the page with the given title is a redirect and, if so, returns the title of
+// the page it points to, e.g. "United Kingdom" for "UK", without fetching the target. A redirect to another
+// redirect returns the title at the end of the chain, and a redirect to a section the title of the page holding
+// it. A title that isn't a redirect returns an empty target and false, or ErrPageNotFound when no page has that
+// title. It ignores the client's KeepRedirects.
+func (c *Client) FindRedirectTarget(ctx context.Context, title string) (string, bool, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["titles"] = c.normalizeTitle(title)
+	params["redirects"] = ""
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err := c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	// A double redirect is listed hop by hop, so the chain is followed from the first hop to its end
+	if redirects := infoResponse.Query.Redirects; len(redirects) > 0 {
+		targets := make(map[string]string, len(redirects))
+
+		for _, redirect := range redirects {
+			targets[redirect.From] = redirect.To
+		}
+
+		target := redirects[0].To
+
+		for range redirects {
+			next, ok := targets[target]
+
+			if !ok {
+				break
+			}
+
+			target = next
+		}
+
+		return c.decodeText(target), true, nil
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if page.exists() {
+			return "", false, nil
+		}
+	}
+
+	return "", false, fmt.Errorf("%w: %s", ErrPageNotFound, title)
+}
+
+// GetArticleURLs returns the desktop and mobile urls of the article with the given page id using DefaultClient.
+func GetArticleURLs(pageId int) (desktop, mobile string, err error) {
+	return DefaultClient.GetArticleURLs(context.Background(), pageId)
+}
+
+// GetArticleURLs returns the desktop and mobile urls of the article with the given page id. The mobile url
+// points at the same article on the m. subdomain of the wiki's host, e.g. https://en.m.wikipedia.org/wiki/Go.
+func (c *Client) GetArticleURLs(ctx context.Context, pageId int) (desktop, mobile string, err error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info"
+	params["inprop"] = "url"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err = c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if page.Pageid == pageId && page.exists() && page.FullURL != "" {
+			mobile, err = mobileURL(page.FullURL)
+
+			if err != nil {
+				return "", "", err
+			}
+
+			return page.FullURL, mobile, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+}
+
+// GetPageSize returns the size in bytes of the article with the given page id using DefaultClient.
+func GetPageSize(pageId int) (int, error) {
+	return DefaultClient.GetPageSize(context.Background(), pageId)
+}
+
+// GetPageSize returns the size in bytes of the wikitext of the article with the given page id. It is a cheaper
+// measure of an article's size than its word count.
+func (c *Client) GetPageSize(ctx context.Context, pageId int) (int, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err := c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if page.Pageid == pageId && page.exists() {
+			return page.Length, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+}
+
+// GetProtection returns the protection of the article with the given page id using DefaultClient.
+func GetProtection(pageId int) ([]ProtectionInfo, error) {
+	return DefaultClient.GetProtection(context.Background(), pageId)
+}
+
+// GetProtection returns the protection of the article with the given page id, one entry per restricted action.
+// An empty slice means the article is unprotected.
+func (c *Client) GetProtection(ctx context.Context, pageId int) ([]ProtectionInfo, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info"
+	params["inprop"] = "protection"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err := c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if page.Pageid == pageId && page.exists() {
+			return append(make([]ProtectionInfo, 0, len(page.Protection)), page.Protection...), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+}
+
+// EditLevel is who may edit an article, as reported by GetEditLevel.
+type EditLevel string
+
+const (
+	// Open is the level of an article anyone may edit.
+	Open EditLevel = "open"
+
+	// SemiProtected is the level of an article only established accounts may edit.
+	SemiProtected EditLevel = "semi"
+
+	// FullyProtected is the level of an article only administrators, or similarly trusted editors, may edit.
+	FullyProtected EditLevel = "full"
+)
+
+// semiProtectionLevels lists the edit protection levels that still let established accounts edit.
+var semiProtectionLevels = map[string]bool{
+	"autoconfirmed":     true,
+	"extendedconfirmed": true,
+}
+
+// GetEditLevel reports who may edit the article with the given title using DefaultClient.
+func GetEditLevel(title string) (EditLevel, error) {
+	return DefaultClient.GetEditLevel(context.Background(), title)
+}
+
+// GetEditLevel reports who may edit the article with the given title, as a badge-friendly summary of its edit
+// protection; see GetProtection for the full details. An article without edit protection is Open. The
+// autoconfirmed level, requiring an account a few days old, and the extendedconfirmed level, requiring 30 days
+// and 500 edits, are SemiProtected. Any other level, such as sysop or templateeditor, is FullyProtected.
+// Protections of other actions, such as moving, don't count. Redirects are followed unless the client keeps them.
+// It returns ErrPageNotFound when no article has the title.
+func (c *Client) GetEditLevel(ctx context.Context, title string) (EditLevel, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info"
+	params["inprop"] = "protection"
+	params["titles"] = c.normalizeTitle(title)
+	c.followRedirects(params)
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err := c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if !page.exists() {
+			continue
+		}
+
+		level := Open
+
+		for _, protection := range page.Protection {
+			if protection.Type != "edit" {
+				continue
+			}
+
+			if !semiProtectionLevels[protection.Level] {
+				return FullyProtected, nil
+			}
+
+			level = SemiProtected
+		}
+
+		return level, nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrPageNotFound, title)
+}
+
+// mobileURL rewrites a desktop url to its mobile equivalent by inserting the m. subdomain after the first label of
+// its host, whatever the project, e.g. en.wiktionary.org becomes en.m.wiktionary.org. A leading www. is replaced
+// instead, as in m.wikidata.org. Hosts with no subdomain, such as IP addresses and localhost, are left unchanged.
+func mobileURL(desktop string) (string, error) {
+	u, err := url.Parse(desktop)
+
+	if err != nil {
+		return "", err
+	}
+
+	host := u.Hostname()
+	first, domain, ok := strings.Cut(host, ".")
+
+	if !ok || net.ParseIP(host) != nil || strings.HasPrefix(domain, "m.") || first == "m" {
+		return u.String(), nil
+	}
+
+	if first == "www" {
+		host = "m." + domain
+	} else {
+		host = first + ".m." + domain
+	}
+
+	if port := u.Port(); port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+
+	u.Host = host
+
+	return u.String(), nil
+}
+
+// ResolveCurrentTitle returns the current title of the page with the given id using DefaultClient.
+func ResolveCurrentTitle(pageId int) (string, error) {
+	return DefaultClient.ResolveCurrentTitle(context.Background(), pageId)
+}
+
+// ResolveCurrentTitle returns the current title of the page with the given id. Page ids stay the same when an
+// article is renamed, so a stored id finds the article under its new title. It returns ErrPageNotFound when no
+// page has the id any more, e.g. because it was deleted.
+func (c *Client) ResolveCurrentTitle(ctx context.Context, pageId int) (string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err := c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if page.Pageid == pageId && page.exists() {
+			return c.decodeText(page.Title), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+}
@@ -0,0 +1,69 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFindRedirectTarget(t *testing.T) {
+	tests := []struct {
+		name         string
+		fixture      string
+		wantTarget   string
+		wantRedirect bool
+		wantErr      error
+	}{
+		{"redirect", "info_redirect.json", "United Kingdom", true, nil},
+		{"double redirect", "info_double_redirect.json", "Go (programming language)", true, nil},
+		{"not a redirect", "info_article.json", "", false, nil},
+		{"missing", "info_missing.json", "", false, ErrPageNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveFixture(t, test.fixture))
+
+			target, redirect, err := client.FindRedirectTarget(context.Background(), "title")
+
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("FindRedirectTarget() error = %v, want %v", err, test.wantErr)
+			}
+
+			if target != test.wantTarget || redirect != test.wantRedirect {
+				t.Errorf("FindRedirectTarget() = %q, %t, want %q, %t", target, redirect, test.wantTarget, test.wantRedirect)
+			}
+		})
+	}
+}
+
+func TestMobileURL(t *testing.T) {
+	tests := []struct {
+		desktop string
+		want    string
+	}{
+		{"https://en.wikipedia.org/wiki/Go", "https://en.m.wikipedia.org/wiki/Go"},
+		{"https://fr.wiktionary.org/wiki/chat", "https://fr.m.wiktionary.org/wiki/chat"},
+		{"https://commons.wikimedia.org/wiki/Main_Page", "https://commons.m.wikimedia.org/wiki/Main_Page"},
+		{"https://www.wikidata.org/wiki/Q37227", "https://m.wikidata.org/wiki/Q37227"},
+		{"https://wiki.example.com:8443/wiki/Go", "https://wiki.m.example.com:8443/wiki/Go"},
+		{"https://en.m.wikipedia.org/wiki/Go", "https://en.m.wikipedia.org/wiki/Go"},
+		{"https://m.wikidata.org/wiki/Q37227", "https://m.wikidata.org/wiki/Q37227"},
+		{"http://127.0.0.1:8080/wiki/Go", "http://127.0.0.1:8080/wiki/Go"},
+		{"http://localhost:8080/wiki/Go", "http://localhost:8080/wiki/Go"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desktop, func(t *testing.T) {
+			got, err := mobileURL(test.desktop)
+
+			if err != nil {
+				t.Fatalf("mobileURL() error = %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("mobileURL() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
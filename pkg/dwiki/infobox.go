@@ -0,0 +1,212 @@
+package dwiki
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// infoboxRowPattern matches the content of a labelled infobox row, capturing the label and the data cell's
+	// content.
+	infoboxRowPattern = regexp.MustCompile(`(?s)^\s*<th[^>]*>(.*?)</th>\s*<td[^>]*>(.*?)</td>`)
+
+	// lineBreakPattern matches the tags that break the content of an infobox cell into lines.
+	lineBreakPattern = regexp.MustCompile(`<br\s*/?>|</li>|</div>|</p>`)
+
+	// yearPattern matches a year of the common era.
+	yearPattern = regexp.MustCompile(`\b\d{3,4}\b`)
+)
+
+// digestLabels lists the infobox labels an infobox digest is made of, in the order they appear in it. Born and
+// Died are shortened to their year.
+var digestLabels = []string{"Born", "Died", "Nationality", "Occupation", "Fields", "Known for"}
+
+// minDigestFields is the fewest recognized fields an infobox needs for a digest, so that infoboxes of
+// other kinds of articles sharing a label don't get one.
+const minDigestFields = 2
+
+// maxDigestValueLength is the most characters of a field's value shown in an infobox digest.
+const maxDigestValueLength = 40
+
+// InfoboxField is a labelled row of an article's infobox, as returned by GetInfobox.
+type InfoboxField struct {
+	Label string `json:"label"`
+
+	// Value is the plain text of the row's data, with each line of a list or multi-line value on its own line.
+	Value string `json:"value"`
+}
+
+// GetInfobox returns the labelled rows of the infobox of the article with the given page id using DefaultClient.
+func GetInfobox(pageId int) ([]InfoboxField, error) {
+	return DefaultClient.GetInfobox(context.Background(), pageId)
+}
+
+// GetInfobox returns the labelled rows of the infobox of the article with the given page id, the summary table
+// beside the intro, in order, as plain text without footnote markers. Rows without a label, such as the image and
+// its caption, are left out. The result is empty if the article has no infobox. It makes a single request for
+// the parsed HTML of the lead section.
+func (c *Client) GetInfobox(ctx context.Context, pageId int) ([]InfoboxField, error) {
+	params := make(map[string]string)
+
+	params["action"] = "parse"
+	params["pageid"] = strconv.Itoa(pageId)
+	params["prop"] = "text"
+	params["section"] = "0"
+	params["disableeditsection"] = "1"
+	params["format"] = "json"
+
+	var parseResponse parseResponse
+
+	err := c.getJSON(ctx, params, &parseResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return infoboxFields(parseResponse.Parse.Text), nil
+}
+
+// infoboxFields returns the labelled rows of the first infobox in the article HTML.
+func infoboxFields(text string) []InfoboxField {
+	fields := make([]InfoboxField, 0)
+
+	depth := 0
+	start := -1
+
+	for _, match := range tableTagPattern.FindAllStringSubmatchIndex(text, -1) {
+		if !strings.HasPrefix(text[match[0]:match[1]], "</") {
+			if start < 0 && match[2] >= 0 && strings.Contains(" "+text[match[2]:match[3]]+" ", " infobox ") {
+				start = match[1]
+				depth = 0
+			}
+
+			depth++
+			continue
+		}
+
+		depth--
+
+		if start < 0 || depth > 0 {
+			continue
+		}
+
+		for _, tableRow := range tableRowPattern.FindAllStringSubmatch(text[start:match[0]], -1) {
+			row := infoboxRowPattern.FindStringSubmatch(tableRow[1])
+
+			if row == nil {
+				continue
+			}
+
+			label := strings.Join(strings.Fields(stripTags(referencePattern.ReplaceAllString(row[1], ""))), " ")
+
+			value := lineBreakPattern.ReplaceAllString(referencePattern.ReplaceAllString(row[2], ""), "\n")
+			lines := make([]string, 0)
+
+			for _, line := range strings.Split(value, "\n") {
+				line = strings.Join(strings.Fields(stripTags(line)), " ")
+
+				if line != "" {
+					lines = append(lines, line)
+				}
+			}
+
+			if label != "" && len(lines) > 0 {
+				fields = append(fields, InfoboxField{Label: label, Value: strings.Join(lines, "\n")})
+			}
+		}
+
+		break
+	}
+
+	return fields
+}
+
+// infoboxDigest returns a one-line digest of the infobox fields, e.g. "Born: 1879 · Died: 1955 · Fields: Physics",
+// or an empty string if too few of them are recognized.
+func infoboxDigest(fields []InfoboxField) string {
+	values := make(map[string]string)
+
+	for _, field := range fields {
+		if _, ok := values[field.Label]; !ok {
+			values[field.Label] = field.Value
+		}
+	}
+
+	parts := make([]string, 0, len(digestLabels))
+
+	for _, label := range digestLabels {
+		value, ok := values[label]
+
+		if !ok {
+			continue
+		}
+
+		// Keep the first line, e.g. the first of several occupations
+		value, _, _ = strings.Cut(value, "\n")
+
+		if label == "Born" || label == "Died" {
+			value = yearPattern.FindString(value)
+
+			if value == "" {
+				continue
+			}
+		}
+
+		parts = append(parts, label+": "+truncate(value, maxDigestValueLength, DefaultEllipsis))
+	}
+
+	if len(parts) < minDigestFields {
+		return ""
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// GetInfoboxDigest returns a one-line digest of the infobox of the article with the given page id using
+// DefaultClient.
+func GetInfoboxDigest(pageId int) (string, error) {
+	return DefaultClient.GetInfoboxDigest(context.Background(), pageId)
+}
+
+// GetInfoboxDigest returns a one-line digest of the infobox of the article with the given page id, made of the
+// fields biographies usually have, e.g. "Born: 1879 · Died: 1955 · Fields: Physics", with dates shortened to their
+// year and each value to its first line. It is kept conservative: the digest is empty, not an error, unless at
+// least two of the fields Born, Died, Nationality, Occupation, Fields and Known for are found, so articles without
+// an infobox or with one of another kind get none.
+func (c *Client) GetInfoboxDigest(ctx context.Context, pageId int) (string, error) {
+	fields, err := c.GetInfobox(ctx, pageId)
+
+	if err != nil {
+		return "", err
+	}
+
+	return infoboxDigest(fields), nil
+}
+
+// withInfoboxDigest prepends the digest of the article's infobox to the summary, when the client asks for it and
+// the article has a recognizable one. The digest is a decoration, so a failed lookup only drops it.
+func (c *Client) withInfoboxDigest(ctx context.Context, pageId int, summary string) string {
+	if !c.InfoboxDigest {
+		return summary
+	}
+
+	digest, err := c.GetInfoboxDigest(ctx, pageId)
+
+	if err != nil {
+		if c.Logger != nil {
+			c.Logger.LogAttrs(ctx, slog.LevelWarn, "wikipedia infobox lookup failed, summary has no digest",
+				slog.String("error", err.Error()))
+		}
+
+		return summary
+	}
+
+	if digest == "" {
+		return summary
+	}
+
+	return digest + "\n\n" + summary
+}
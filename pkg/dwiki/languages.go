@@ -0,0 +1,198 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type langLinksResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Pageid    int    `json:"pageid"`
+			Title     string `json:"title"`
+			LangLinks []struct {
+				Lang  string `json:"lang"`
+				Title string `json:"title"`
+			} `json:"langlinks"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// withLanguage returns a copy of the client that queries the given language edition. The copy shares the
+// client's state, such as its rate limit. A client with Endpoints has them rewritten for the edition: each
+// endpoint whose host starts with the client's language, as in https://en.wikipedia.org, gets that label replaced,
+// and the others, which don't say how to reach another edition, are dropped. If none is left, the copy's requests
+// fail rather than query the wrong edition.
+func (c *Client) withLanguage(lang string) *Client {
+	c.state()
+
+	clone := *c
+	clone.Language = lang
+
+	if len(c.Endpoints) == 0 || lang == c.language() {
+		return &clone
+	}
+
+	clone.Endpoints = make([]string, 0, len(c.Endpoints))
+
+	for _, endpoint := range c.Endpoints {
+		parsed, err := url.Parse(endpoint)
+
+		if err != nil {
+			continue
+		}
+
+		label, rest, ok := strings.Cut(parsed.Host, ".")
+
+		if !ok || label != c.language() {
+			continue
+		}
+
+		parsed.Host = lang + "." + rest
+		clone.Endpoints = append(clone.Endpoints, parsed.String())
+	}
+
+	if len(clone.Endpoints) == 0 {
+		clone.endpointErr = fmt.Errorf("no endpoint can be rewritten for language %q", lang)
+	}
+
+	return &clone
+}
+
+// getLangLinks returns the titles of the article's equivalents in other languages, keyed by language code.
+func (c *Client) getLangLinks(ctx context.Context, title string) (map[string]string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "langlinks"
+	params["lllimit"] = "max"
+	params["titles"] = title
+	c.followRedirects(params)
+	params["format"] = "json"
+
+	var langLinksResponse langLinksResponse
+
+	err := c.getJSON(ctx, params, &langLinksResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(map[string]string)
+
+	for _, page := range langLinksResponse.Query.Pages {
+		for _, link := range page.LangLinks {
+			links[link.Lang] = link.Title
+		}
+	}
+
+	return links, nil
+}
+
+// GetSummaryInLanguages returns summaries of the article in each of the given languages using DefaultClient.
+func GetSummaryInLanguages(title string, langs []string) (map[string]string, error) {
+	return DefaultClient.GetSummaryInLanguages(context.Background(), title, langs)
+}
+
+// GetSummaryInLanguages resolves the article with the given title to its equivalent in each of the given
+// languages through its language links and fetches the summaries concurrently. The result maps language
+// code to summary text. Languages without an equivalent article are omitted. Failed fetches are also
+// omitted, and reported together in the returned error alongside the summaries that succeeded.
+func (c *Client) GetSummaryInLanguages(ctx context.Context, title string, langs []string) (map[string]string, error) {
+	links, err := c.getLangLinks(ctx, title)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The article is its own equivalent in the client's language
+	links[c.language()] = title
+
+	summaries := make(map[string]string)
+	errs := make([]error, 0)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, lang := range langs {
+		localTitle, ok := links[lang]
+
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(lang, localTitle string) {
+			defer wg.Done()
+
+			lc := c.withLanguage(lang)
+
+			page, err := lc.getExtractByTitle(ctx, localTitle)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", lang, err))
+				return
+			}
+
+			summaries[lang] = lc.preview(page.Extract, page.cut)
+		}(lang, localTitle)
+	}
+
+	wg.Wait()
+
+	return summaries, errors.Join(errs...)
+}
+
+// SearchMultiLang searches for the topic in several language editions at once using DefaultClient.
+func SearchMultiLang(topic string, langs []string, opts ...Option) (map[string][]Article, error) {
+	return DefaultClient.SearchMultiLang(context.Background(), topic, langs, opts...)
+}
+
+// SearchMultiLang runs the same search against each of the given language editions concurrently and returns the
+// results keyed by language. A failed language doesn't stop the others: its results are left out and its error,
+// prefixed with the language code, is joined into the returned error. The searches share the client's
+// MaxConcurrency and RequestInterval limits.
+func (c *Client) SearchMultiLang(
+	ctx context.Context, topic string, langs []string, opts ...Option,
+) (map[string][]Article, error) {
+	results := make(map[string][]Article)
+	errs := make([]error, 0)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, lang := range langs {
+		wg.Add(1)
+
+		go func(lang string) {
+			defer wg.Done()
+
+			articles, err := c.withLanguage(lang).Search(ctx, topic, opts...)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", lang, err))
+				return
+			}
+
+			results[lang] = articles
+		}(lang)
+	}
+
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
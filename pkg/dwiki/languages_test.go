@@ -0,0 +1,55 @@
+package dwiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithLanguageEndpoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    Client
+		lang      string
+		want      []string
+		wantError bool
+	}{
+		{"no endpoints", Client{}, "fr", []string{"https://fr.wikipedia.org"}, false},
+		{
+			"language label rewritten",
+			Client{Endpoints: []string{"https://en.wikipedia.org", "https://en.wikipedia-mirror.example.com/"}},
+			"de",
+			[]string{"https://de.wikipedia.org", "https://de.wikipedia-mirror.example.com/"},
+			false,
+		},
+		{
+			"other language",
+			Client{Language: "sv", Endpoints: []string{"https://sv.wikipedia.org"}},
+			"fi",
+			[]string{"https://fi.wikipedia.org"},
+			false,
+		},
+		{
+			"endpoints without a language label dropped",
+			Client{Endpoints: []string{"https://wiki.example.com", "https://en.wikipedia.org"}},
+			"es",
+			[]string{"https://es.wikipedia.org"},
+			false,
+		},
+		{"no rewritable endpoint", Client{Endpoints: []string{"https://wiki.example.com"}}, "es", nil, true},
+		{"same language", Client{Endpoints: []string{"https://wiki.example.com"}}, "en", []string{"https://wiki.example.com"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.client.withLanguage(test.lang).endpoints()
+
+			if (err != nil) != test.wantError {
+				t.Fatalf("endpoints() error = %v, want error %v", err, test.wantError)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("endpoints() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// maxCoCitationTargets is the most of an article's links GetCoCited looks up the backlinks of.
+const maxCoCitationTargets = 20
+
+type extLinksResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Elcontinue string `json:"elcontinue"`
+		Continue   string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		Pages []struct {
+			Pageid   int    `json:"pageid"`
+			Title    string `json:"title"`
+			ExtLinks []struct {
+				URL string `json:"url"`
+			} `json:"extlinks"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// CountExternalLinks returns the number of external links in the article with the given page id using DefaultClient.
+func CountExternalLinks(pageId int) (int, error) {
+	return DefaultClient.CountExternalLinks(context.Background(), pageId)
+}
+
+// CountExternalLinks returns the number of external links in the article with the given page id, a rough
+// signal of how fleshed-out it is. It follows continuation so that large articles are counted in full.
+func (c *Client) CountExternalLinks(ctx context.Context, pageId int) (int, error) {
+	count := 0
+
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "extlinks"
+	params["ellimit"] = "max"
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	for {
+		var extLinksResponse extLinksResponse
+
+		err := c.getJSON(ctx, params, &extLinksResponse)
+
+		if err != nil {
+			return 0, err
+		}
+
+		for _, page := range extLinksResponse.Query.Pages {
+			count += len(page.ExtLinks)
+		}
+
+		if extLinksResponse.Continue.Elcontinue == "" {
+			break
+		}
+
+		params["elcontinue"] = extLinksResponse.Continue.Elcontinue
+	}
+
+	return count, nil
+}
+
+// GetCoCited returns up to limit articles that link to the same articles as the one with the given page id using
+// DefaultClient.
+func GetCoCited(pageId int, limit int) ([]Article, error) {
+	return DefaultClient.GetCoCited(context.Background(), pageId, limit)
+}
+
+// GetCoCited returns up to limit articles that link to the same articles as the one with the given page id, a
+// measure of relatedness known as co-citation, ranked by how many of those articles they share with it and then
+// by title. To bound the work, only a sample of 20 links spread evenly over the article's links is looked up,
+// and at most 500 backlinks are read for each, so the ranking is an estimate. The lookups run concurrently,
+// within the client's MaxConcurrency and RequestInterval limits. An article without links has no co-cited
+// articles, so the result is then empty.
+func (c *Client) GetCoCited(ctx context.Context, pageId int, limit int) ([]Article, error) {
+	if limit < 1 {
+		return nil, errors.New("limit must be at least 1")
+	}
+
+	parsed, err := c.parsePage(ctx, pageId, "links", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]string, 0, len(parsed.Parse.Links))
+
+	for _, link := range parsed.Parse.Links {
+		if link.Ns == 0 && link.Exists {
+			targets = append(targets, link.Title)
+		}
+	}
+
+	// Spread the sample over all the links rather than taking the first ones, which the API lists by title
+	if len(targets) > maxCoCitationTargets {
+		sample := make([]string, 0, maxCoCitationTargets)
+
+		for i := 0; i < maxCoCitationTargets; i++ {
+			sample = append(sample, targets[i*len(targets)/maxCoCitationTargets])
+		}
+
+		targets = sample
+	}
+
+	shared := make(map[int]int)
+	articles := make(map[int]Article)
+	errs := make([]error, 0)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, target := range targets {
+		wg.Add(1)
+
+		go func(target string) {
+			defer wg.Done()
+
+			backlinks, err := c.getBacklinks(ctx, "bltitle", target)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+
+			for _, backlink := range backlinks {
+				if backlink.PageID == pageId {
+					continue
+				}
+
+				shared[backlink.PageID]++
+				articles[backlink.PageID] = backlink
+			}
+		}(target)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	cocited := make([]Article, 0, len(articles))
+
+	for _, article := range articles {
+		cocited = append(cocited, article)
+	}
+
+	sort.Slice(cocited, func(i, j int) bool {
+		if shared[cocited[i].PageID] != shared[cocited[j].PageID] {
+			return shared[cocited[i].PageID] > shared[cocited[j].PageID]
+		}
+
+		return cocited[i].Title < cocited[j].Title
+	})
+
+	if len(cocited) > limit {
+		cocited = cocited[:limit]
+	}
+
+	return cocited, nil
+}
+
+// maxBacklinkTotal is the most backlinks GetBacklinkCount counts before reporting the count as capped.
+const maxBacklinkTotal = 10000
+
+// GetBacklinkCount returns the number of articles linking to the article with the given title using DefaultClient.
+func GetBacklinkCount(title string) (int, bool, error) {
+	return DefaultClient.GetBacklinkCount(context.Background(), title)
+}
+
+// GetBacklinkCount returns the number of articles linking to the article with the given title, a cheap measure of
+// its popularity. Only links from other articles count, not those from talk or user pages, and links through a
+// redirect to the article aren't followed. The API has no count of its own, so the links are paged through 500
+// at a time. Counting stops at 10000 links, in which case the returned count is a lower bound and the capped
+// result is true. A title without an article counts the links to it all the same.
+func (c *Client) GetBacklinkCount(ctx context.Context, title string) (int, bool, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["list"] = "backlinks"
+	params["bltitle"] = c.normalizeTitle(title)
+	params["blnamespace"] = "0"
+	params["bllimit"] = "max"
+	params["format"] = "json"
+
+	count := 0
+
+	for {
+		var backlinksResponse backlinksResponse
+
+		err := c.getJSON(ctx, params, &backlinksResponse)
+
+		if err != nil {
+			return 0, false, err
+		}
+
+		count += len(backlinksResponse.Query.Backlinks)
+
+		if backlinksResponse.Continue.Blcontinue == "" {
+			return count, false, nil
+		}
+
+		if count >= maxBacklinkTotal {
+			return count, true, nil
+		}
+
+		params["blcontinue"] = backlinksResponse.Continue.Blcontinue
+	}
+}
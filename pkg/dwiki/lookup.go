@@ -0,0 +1,176 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Candidate is a search result scored by LookUpCandidates.
+type Candidate struct {
+	Article
+
+	// Score rates how likely the article is to be the one meant by the topic, from 0 to 1.
+	Score float64 `json:"score"`
+}
+
+// LookUp returns the article that best matches the topic using DefaultClient.
+func LookUp(topic string) (*Article, error) {
+	return DefaultClient.LookUp(context.Background(), topic)
+}
+
+// LookUp searches for the topic, picks the best candidate as scored by LookUpCandidates and returns its details,
+// without asking the user to choose.
+func (c *Client) LookUp(ctx context.Context, topic string) (*Article, error) {
+	candidates, err := c.LookUpCandidates(ctx, topic)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.lookUpBest(ctx, candidates)
+}
+
+// defaultMaxAlternatives is the number of alternatives LookUpWithAlternatives returns when given no limit.
+const defaultMaxAlternatives = 5
+
+// LookUpWithAlternatives returns the article that best matches the topic and the other candidates it was chosen
+// among using DefaultClient.
+func LookUpWithAlternatives(topic string, limit int) (*Article, []Candidate, error) {
+	return DefaultClient.LookUpWithAlternatives(context.Background(), topic, limit)
+}
+
+// LookUpWithAlternatives picks and returns the same article as LookUp, along with up to limit of the other
+// candidates it was chosen among, best first, with their scores, e.g. to offer "did you mean" links beside the
+// article. The alternatives come from the same search, so it makes no more requests than LookUp. A limit of 0 or
+// less returns up to five alternatives.
+func (c *Client) LookUpWithAlternatives(ctx context.Context, topic string, limit int) (*Article, []Candidate, error) {
+	if limit <= 0 {
+		limit = defaultMaxAlternatives
+	}
+
+	candidates, err := c.LookUpCandidates(ctx, topic)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	article, err := c.lookUpBest(ctx, candidates)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alternatives := append(make([]Candidate, 0), candidates[1:min(limit+1, len(candidates))]...)
+
+	return article, alternatives, nil
+}
+
+// lookUpBest returns the details of the best of the scored candidates.
+func (c *Client) lookUpBest(ctx context.Context, candidates []Candidate) (*Article, error) {
+	if len(candidates) == 0 {
+		return nil, errors.New("no search results found")
+	}
+
+	article, err := c.GetArticleDetails(ctx, candidates[0].PageID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The word count is only known from the search results
+	article.WordCount = candidates[0].WordCount
+
+	return article, nil
+}
+
+// LookUpCandidates returns the search results for the topic scored by LookUp using DefaultClient.
+func LookUpCandidates(topic string) ([]Candidate, error) {
+	return DefaultClient.LookUpCandidates(context.Background(), topic)
+}
+
+// LookUpCandidates searches for the topic, disambiguation pages excluded, and returns the results best first.
+// Each is scored on how closely its title matches the topic, weighted most, its rank in the search results
+// and, weighted least, its word count, so that a substantial article beats a stub with a similar title.
+func (c *Client) LookUpCandidates(ctx context.Context, topic string) ([]Candidate, error) {
+	articles, err := c.Search(ctx, topic)
+
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Candidate, 0, len(articles))
+
+	for i, article := range articles {
+		rank := 1 - float64(i)/float64(len(articles))
+
+		// Word counts grow roughly exponentially between stubs and long articles, so they are compared on a
+		// log scale that saturates at 100,000 words
+		size := math.Min(1, math.Log10(float64(article.WordCount)+1)/5)
+
+		candidates = append(candidates, Candidate{
+			Article: article,
+			Score:   0.6*titleSimilarity(topic, article.Title) + 0.25*rank + 0.15*size,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	return candidates, nil
+}
+
+// titleSimilarity rates how closely a title matches a topic from 0 to 1: 1 for the same words ignoring case and
+// punctuation, otherwise the share of words they have in common. A parenthetical qualifier such as the
+// "(programming language)" in "Go (programming language)" only counts half.
+func titleSimilarity(topic, title string) float64 {
+	topicWords := words(topic)
+
+	if len(topicWords) == 0 {
+		return 0
+	}
+
+	base, qualifier, _ := strings.Cut(title, "(")
+
+	baseWords := words(base)
+
+	if strings.Join(baseWords, " ") == strings.Join(topicWords, " ") {
+		if qualifier == "" {
+			return 1
+		}
+
+		return 0.9
+	}
+
+	titleWords := make(map[string]float64)
+
+	for _, word := range baseWords {
+		titleWords[word] = 1
+	}
+
+	for _, word := range words(qualifier) {
+		if titleWords[word] == 0 {
+			titleWords[word] = 0.5
+		}
+	}
+
+	matched := 0.0
+
+	for _, word := range topicWords {
+		matched += titleWords[word]
+	}
+
+	// Divide by the larger vocabulary so that long titles containing the topic don't score as exact matches
+	return matched / math.Max(float64(len(topicWords)), float64(len(titleWords))) * 0.8
+}
+
+// words splits text into its lowercase words, dropping punctuation.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
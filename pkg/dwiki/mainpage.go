@@ -0,0 +1,68 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// GetMainPage returns the wiki's main page using DefaultClient.
+func GetMainPage() (*Article, error) {
+	return DefaultClient.GetMainPage(context.Background())
+}
+
+// GetMainPage returns the main page of the client's wiki, e.g. "Main Page" on the English Wikipedia and
+// "Wikipédia:Accueil principal" on the French one, with its title, page id, url and the plain text of its content
+// as the extract and preview. The title differs between wikis, so it is looked up in the site information first,
+// costing one extra request. Main pages are built from boxes rather than prose, so the extract is a rough
+// rendering and may be empty.
+func (c *Client) GetMainPage(ctx context.Context) (*Article, error) {
+	title, err := c.getMainPageTitle(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+
+	params["titles"] = title
+	c.followRedirects(params)
+
+	page, err := c.queryPage(ctx, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Missing || page.Invalid {
+		return nil, fmt.Errorf("%w: %s", ErrPageNotFound, title)
+	}
+
+	return c.extractArticle(page), nil
+}
+
+// getMainPageTitle looks up the title of the wiki's main page in its site information.
+func (c *Client) getMainPageTitle(ctx context.Context) (string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["meta"] = "siteinfo"
+	params["siprop"] = "general"
+	params["format"] = "json"
+
+	var siteInfoResponse siteInfoResponse
+
+	err := c.getJSON(ctx, params, &siteInfoResponse)
+
+	if err != nil {
+		return "", err
+	}
+
+	title := siteInfoResponse.Query.General.MainPage
+
+	if title == "" {
+		return "", errors.New("unexpected response: no main page returned")
+	}
+
+	return title, nil
+}
@@ -0,0 +1,63 @@
+package dwiki
+
+import "context"
+
+// templatePrefix is the namespace prefix of template titles.
+const templatePrefix = "Template:"
+
+// maintenanceTemplates maps the maintenance templates of the English Wikipedia, the cleanup banners and inline
+// tags marking problems with an article, to a readable name for the problem.
+var maintenanceTemplates = map[string]string{
+	templatePrefix + "Advert":                "Written like an advertisement",
+	templatePrefix + "Citation needed":       "Citation needed",
+	templatePrefix + "Cleanup":               "Needs cleanup",
+	templatePrefix + "Copy edit":             "Needs copy editing",
+	templatePrefix + "Dead end":              "Dead end",
+	templatePrefix + "Lead too short":        "Lead too short",
+	templatePrefix + "More citations needed": "Needs additional citations",
+	templatePrefix + "Notability":            "Notability in question",
+	templatePrefix + "Original research":     "May contain original research",
+	templatePrefix + "Orphan":                "Orphan",
+	templatePrefix + "POV":                   "Neutrality disputed",
+	templatePrefix + "Primary sources":       "Relies on primary sources",
+	templatePrefix + "Tone":                  "Inappropriate tone",
+	templatePrefix + "Unreferenced":          "Unreferenced",
+	templatePrefix + "Unreliable sources":    "Unreliable sources",
+	templatePrefix + "Update":                "Needs updating",
+	templatePrefix + "Very long":             "Very long",
+}
+
+// GetMaintenanceTags returns the maintenance tags on the article with the given page id using DefaultClient.
+func GetMaintenanceTags(pageId int) ([]string, error) {
+	return DefaultClient.GetMaintenanceTags(context.Background(), pageId)
+}
+
+// GetMaintenanceTags returns readable names for the problems the maintenance templates on the article with the
+// given page id mark, e.g. "Needs additional citations" for the banner asking for more citations or "Citation
+// needed" for the inline tag, a signal of the article's health. Each name appears once, in the order of the
+// templates' titles. The result is empty for an article without any. Only the templates of the English
+// Wikipedia are recognized, so articles of other language editions get none. It reads the article's templates
+// with GetArticleTemplates and returns ErrPageNotFound if there is no page with the id.
+func (c *Client) GetMaintenanceTags(ctx context.Context, pageId int) ([]string, error) {
+	templates, err := c.GetArticleTemplates(ctx, pageId, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, template := range templates {
+		tag, ok := maintenanceTemplates[template]
+
+		if !ok || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
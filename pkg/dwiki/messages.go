@@ -0,0 +1,41 @@
+package dwiki
+
+// Messages holds the user-facing text that GetMatchingArticles and GetWikiArticleSummary write, so that front-ends
+// can show it in another language. Empty fields use the English text of DefaultMessages. The label of the link
+// that follows a summary is set separately with the client's MoreLinkLabel.
+type Messages struct {
+	// NoResults is written instead of the result list when a search finds nothing.
+	NoResults string
+
+	// SearchResults is the heading of the numbered result list.
+	SearchResults string
+
+	// ChoicePrompt asks for the number of the article to read.
+	ChoicePrompt string
+}
+
+// DefaultMessages is the English text used for the fields of a client's Messages that are empty.
+var DefaultMessages = Messages{
+	NoResults:     "No search results found.",
+	SearchResults: "Search results:",
+	ChoicePrompt:  "Enter the number of the article you want to read: ",
+}
+
+// messages returns the client's Messages with the empty fields filled in from DefaultMessages.
+func (c *Client) messages() Messages {
+	messages := c.Messages
+
+	if messages.NoResults == "" {
+		messages.NoResults = DefaultMessages.NoResults
+	}
+
+	if messages.SearchResults == "" {
+		messages.SearchResults = DefaultMessages.SearchResults
+	}
+
+	if messages.ChoicePrompt == "" {
+		messages.ChoicePrompt = DefaultMessages.ChoicePrompt
+	}
+
+	return messages
+}
@@ -0,0 +1,92 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+)
+
+// MobileSection is a section of an article in the mobile format. Text holds the section's HTML.
+type MobileSection struct {
+	ID     int    `json:"id"`
+	Level  int    `json:"level,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Anchor string `json:"anchor,omitempty"`
+	Text   string `json:"text"`
+}
+
+// MobileArticle is an article in the mobile-optimized format, split into the lead section and the remaining sections.
+type MobileArticle struct {
+	Title        string          `json:"title"`
+	DisplayTitle string          `json:"displaytitle"`
+	Description  string          `json:"description,omitempty"`
+	LastModified string          `json:"lastmodified"`
+	Lead         MobileSection   `json:"lead"`
+	Sections     []MobileSection `json:"sections"`
+}
+
+type mobileSectionsResponse struct {
+	Lead struct {
+		ID              int    `json:"id"`
+		LastModified    string `json:"lastmodified"`
+		DisplayTitle    string `json:"displaytitle"`
+		NormalizedTitle string `json:"normalizedtitle"`
+		Description     string `json:"description"`
+		Sections        []struct {
+			ID   int    `json:"id"`
+			Text string `json:"text"`
+		} `json:"sections"`
+	} `json:"lead"`
+	Remaining struct {
+		Sections []struct {
+			ID       int    `json:"id"`
+			Text     string `json:"text"`
+			Toclevel int    `json:"toclevel"`
+			Line     string `json:"line"`
+			Anchor   string `json:"anchor"`
+		} `json:"sections"`
+	} `json:"remaining"`
+}
+
+// GetMobileSections fetches the article with the given title in the mobile format using DefaultClient.
+func GetMobileSections(title string) (*MobileArticle, error) {
+	return DefaultClient.GetMobileSections(context.Background(), title)
+}
+
+// GetMobileSections fetches the article with the given title from the REST /page/mobile-sections endpoint,
+// returning the lead section and the remaining sections as app-ready HTML.
+func (c *Client) GetMobileSections(ctx context.Context, title string) (*MobileArticle, error) {
+	var mobileSectionsResponse mobileSectionsResponse
+
+	err := c.getRESTJSON(ctx, "/page/mobile-sections/"+restTitle(title), &mobileSectionsResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lead := mobileSectionsResponse.Lead
+
+	if len(lead.Sections) == 0 {
+		return nil, errors.New("no lead section found")
+	}
+
+	article := &MobileArticle{
+		Title:        lead.NormalizedTitle,
+		DisplayTitle: lead.DisplayTitle,
+		Description:  lead.Description,
+		LastModified: lead.LastModified,
+		Lead:         MobileSection{ID: lead.Sections[0].ID, Text: lead.Sections[0].Text},
+		Sections:     make([]MobileSection, 0, len(mobileSectionsResponse.Remaining.Sections)),
+	}
+
+	for _, section := range mobileSectionsResponse.Remaining.Sections {
+		article.Sections = append(article.Sections, MobileSection{
+			ID:     section.ID,
+			Level:  section.Toclevel,
+			Title:  section.Line,
+			Anchor: section.Anchor,
+			Text:   section.Text,
+		})
+	}
+
+	return article, nil
+}
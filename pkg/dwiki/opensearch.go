@@ -0,0 +1,54 @@
+package dwiki
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// SearchOpenSearchFormat searches for the topic and returns the results as OpenSearch suggestions using
+// DefaultClient.
+func SearchOpenSearchFormat(topic string, limit int) ([]byte, error) {
+	return DefaultClient.SearchOpenSearchFormat(context.Background(), topic, limit)
+}
+
+// SearchOpenSearchFormat searches for up to limit articles matching the topic and returns them in the OpenSearch
+// suggestions format used by browser search plugins: a JSON array holding the query, the result titles, their
+// short descriptions and their urls, e.g.
+// ["go",["Go (programming language)"],["Programming language"],["https://..."]].
+func (c *Client) SearchOpenSearchFormat(ctx context.Context, topic string, limit int) ([]byte, error) {
+	articles, err := c.Search(ctx, topic, WithLimit(limit), WithDescriptions())
+
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0, len(articles))
+	descriptions := make([]string, 0, len(articles))
+	urls := make([]string, 0, len(articles))
+
+	for _, article := range articles {
+		articleURL, err := c.articleURL(article.Title)
+
+		if err != nil {
+			return nil, err
+		}
+
+		titles = append(titles, article.Title)
+		descriptions = append(descriptions, article.Description)
+		urls = append(urls, articleURL)
+	}
+
+	return json.Marshal([]any{topic, titles, descriptions, urls})
+}
+
+// articleURL returns the url of the article with the given title on the client's first endpoint.
+func (c *Client) articleURL(title string) (string, error) {
+	endpoints, err := c.endpoints()
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(endpoints[0], "/") + "/wiki/" + restTitle(title), nil
+}
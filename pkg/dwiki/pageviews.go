@@ -0,0 +1,114 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// pageviewsEndpoint is the base url of the Wikimedia REST API serving the pageviews of every wiki.
+const pageviewsEndpoint = "https://wikimedia.org"
+
+type topPageviewsResponse struct {
+	Items []struct {
+		Project  string `json:"project"`
+		Articles []struct {
+			Article string `json:"article"`
+			Views   int    `json:"views"`
+			Rank    int    `json:"rank"`
+		} `json:"articles"`
+	} `json:"items"`
+}
+
+// GetMostViewed returns up to limit of the most viewed articles on the given date using DefaultClient.
+func GetMostViewed(date time.Time, limit int) ([]Article, error) {
+	return DefaultClient.GetMostViewed(context.Background(), date, limit)
+}
+
+// GetMostViewed returns up to limit of the most viewed articles on the given UTC date, most viewed first, with
+// their Views set. It reads the /metrics/pageviews/top endpoint of the Wikimedia REST API at wikimedia.org,
+// counting views from every platform. The wiki's main page, whose title is looked up in its site information at
+// the cost of one extra request, and special pages such as "Special:Search" are left out. The returned articles
+// carry only their title and views, not their page id. The statistics for a day are published the day after, so
+// recent dates may fail with a 404 rest api error.
+func (c *Client) GetMostViewed(ctx context.Context, date time.Time, limit int) ([]Article, error) {
+	if limit < 1 {
+		return nil, errors.New("limit must be at least 1")
+	}
+
+	project, err := c.pageviewsProject()
+
+	if err != nil {
+		return nil, err
+	}
+
+	mainPage, err := c.getMainPageTitle(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var topPageviewsResponse topPageviewsResponse
+
+	path := "/metrics/pageviews/top/" + project + "/all-access/" + date.UTC().Format("2006/01/02")
+
+	err = c.pageviews().getRESTJSON(ctx, path, &topPageviewsResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]Article, 0, limit)
+
+	for _, item := range topPageviewsResponse.Items {
+		for _, entry := range item.Articles {
+			title := strings.ReplaceAll(entry.Article, "_", " ")
+
+			if title == mainPage || title == "-" || strings.HasPrefix(title, "Special:") {
+				continue
+			}
+
+			articles = append(articles, Article{
+				Title: c.decodeText(title),
+				Views: entry.Views,
+			})
+
+			if len(articles) == limit {
+				return articles, nil
+			}
+		}
+	}
+
+	return articles, nil
+}
+
+// pageviews returns a copy of the client that queries the Wikimedia REST API serving pageviews, sharing the
+// client's state.
+func (c *Client) pageviews() *Client {
+	c.state()
+
+	clone := *c
+	clone.Endpoints = []string{pageviewsEndpoint}
+	clone.endpointErr = nil
+	return &clone
+}
+
+// pageviewsProject returns the name the pageviews API knows the client's wiki by, its host without the ".org"
+// suffix, e.g. "en.wikipedia".
+func (c *Client) pageviewsProject() (string, error) {
+	endpoints, err := c.endpoints()
+
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, err := url.Parse(endpoints[0])
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(endpoint.Hostname(), ".org"), nil
+}
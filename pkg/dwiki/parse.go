@@ -0,0 +1,113 @@
+package dwiki
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+type parseResponse struct {
+	apiEnvelope
+
+	Parse struct {
+		Title    string `json:"title"`
+		Pageid   int    `json:"pageid"`
+		Sections []struct {
+			Toclevel int    `json:"toclevel"`
+			Level    string `json:"level"`
+			Line     string `json:"line"`
+			Number   string `json:"number"`
+			Index    string `json:"index"`
+			Anchor   string `json:"anchor"`
+		} `json:"sections"`
+		Links []struct {
+			Ns     int    `json:"ns"`
+			Exists bool   `json:"exists"`
+			Title  string `json:"title"`
+		} `json:"links"`
+		Text string `json:"text"`
+	} `json:"parse"`
+}
+
+// parsePage runs action=parse on the page with the given id for the given props, e.g. "sections" or "links".
+// If section is not empty only that section, by index, is parsed.
+func (c *Client) parsePage(ctx context.Context, pageId int, props string, section string) (*parseResponse, error) {
+	params := make(map[string]string)
+
+	params["action"] = "parse"
+	params["pageid"] = strconv.Itoa(pageId)
+	params["prop"] = props
+	params["format"] = "json"
+
+	if section != "" {
+		params["section"] = section
+	}
+
+	var parseResponse parseResponse
+
+	err := c.getJSON(ctx, params, &parseResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &parseResponse, nil
+}
+
+// findSection returns the index of the first section whose heading matches one of the given headings,
+// ignoring case, or an empty string if there is none.
+func (c *Client) findSection(ctx context.Context, pageId int, headings ...string) (string, error) {
+	parsed, err := c.parsePage(ctx, pageId, "sections", "")
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, section := range parsed.Parse.Sections {
+		for _, heading := range headings {
+			if strings.EqualFold(stripTags(section.Line), heading) {
+				return section.Index, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// GetSeeAlso returns the titles linked from the "See also" section of the article with the given page id using
+// DefaultClient.
+func GetSeeAlso(pageId int) ([]string, error) {
+	return DefaultClient.GetSeeAlso(context.Background(), pageId)
+}
+
+// GetSeeAlso returns the titles of the articles linked from the "See also" section of the article with the
+// given page id, a curated list of related topics. It returns an empty slice when there is no such section, and
+// nil when a request fails.
+func (c *Client) GetSeeAlso(ctx context.Context, pageId int) ([]string, error) {
+	index, err := c.findSection(ctx, pageId, "See also")
+
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0)
+
+	if index == "" {
+		return titles, nil
+	}
+
+	parsed, err := c.parsePage(ctx, pageId, "links", index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, link := range parsed.Parse.Links {
+		// Only keep links to articles, skipping other namespaces such as portals
+		if link.Ns == 0 {
+			titles = append(titles, link.Title)
+		}
+	}
+
+	return titles, nil
+}
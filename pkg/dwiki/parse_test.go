@@ -0,0 +1,77 @@
+package dwiki
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetSeeAlso(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(t *testing.T) http.HandlerFunc
+		want    []string
+		wantErr bool
+	}{
+		{
+			"see also section",
+			func(t *testing.T) http.HandlerFunc {
+				return serveFixtures(t, [][2]string{{"prop=sections", "parse_sections.json"}, {"prop=links", "parse_links.json"}})
+			},
+			[]string{"Comparison of programming languages", "Rust (programming language)"},
+			false,
+		},
+		{
+			"no see also section",
+			func(t *testing.T) http.HandlerFunc {
+				return serveFixtures(t, [][2]string{{"prop=sections", "parse_sections_no_see_also.json"}})
+			},
+			[]string{},
+			false,
+		},
+		{
+			"failed sections request",
+			func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				}
+			},
+			nil,
+			true,
+		},
+		{
+			"failed links request",
+			func(t *testing.T) http.HandlerFunc {
+				sections := serveFixture(t, "parse_sections.json")
+
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Query().Get("prop") == "sections" {
+						sections(w, r)
+						return
+					}
+
+					http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				}
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, test.handler(t))
+
+			titles, err := client.GetSeeAlso(context.Background(), 25039021)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("GetSeeAlso() error = %v, want error %t", err, test.wantErr)
+			}
+
+			if !reflect.DeepEqual(titles, test.want) {
+				t.Errorf("GetSeeAlso() = %#v, want %#v", titles, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+)
+
+type siteInfoResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		General struct {
+			SiteName  string `json:"sitename"`
+			Generator string `json:"generator"`
+			MainPage  string `json:"mainpage"`
+		} `json:"general"`
+	} `json:"query"`
+}
+
+// Ping checks that the API is reachable using DefaultClient.
+func Ping() error {
+	return DefaultClient.Ping(context.Background())
+}
+
+// Ping checks that the API is reachable and answering with valid JSON by requesting the site's general
+// information, one of the cheapest queries it serves. It returns nil when the wiki responded, and the error of
+// the request otherwise; use the context to bound how long it may take.
+func (c *Client) Ping(ctx context.Context) error {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["meta"] = "siteinfo"
+	params["siprop"] = "general"
+	params["format"] = "json"
+
+	var siteInfoResponse siteInfoResponse
+
+	err := c.getJSON(ctx, params, &siteInfoResponse)
+
+	if err != nil {
+		return err
+	}
+
+	if siteInfoResponse.Query.General.SiteName == "" && !c.DryRun {
+		return errors.New("unexpected response: no site information returned")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// defaultPrefetchWorkers is the number of summaries Prefetch fetches at once when the client has no MaxConcurrency.
+const defaultPrefetchWorkers = 4
+
+// Prefetch fetches the summaries of the articles with the given page ids into the client's Cache, so that the
+// GetArticleSummary and WriteArticleSummary calls for them that follow, e.g. for the results a user is likely to
+// open next, are served without waiting for the API. It makes the same requests as WriteArticleSummary, infobox
+// digest included when the client asks for one, and returns once they are all done; call it in a goroutine to
+// prefetch in the background.
+//
+// The summaries are fetched concurrently, by as many workers as the client's MaxConcurrency, or four without
+// one. Every request still goes through the client's limits, so a RequestInterval spaces prefetching out like any
+// other requests, and a prefetch in progress delays the requests made alongside it on the same client. A failed
+// summary doesn't stop the others: its error, prefixed with the page id, is joined into the returned error. It
+// returns ErrNoCache without making any request if the client has no Cache.
+func (c *Client) Prefetch(ctx context.Context, pageIds []int) error {
+	if c.Cache == nil {
+		return ErrNoCache
+	}
+
+	workers := c.MaxConcurrency
+
+	if workers <= 0 {
+		workers = defaultPrefetchWorkers
+	}
+
+	ids := make(chan int)
+	errs := make([]error, 0)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for range min(workers, len(pageIds)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for pageId := range ids {
+				_, err := c.WriteArticleSummary(ctx, pageId, io.Discard)
+
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("%d: %w", pageId, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	// Stop handing out page ids once the context is done, rather than failing each of the rest
+send:
+	for _, pageId := range pageIds {
+		select {
+		case ids <- pageId:
+		case <-ctx.Done():
+			break send
+		}
+	}
+
+	close(ids)
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		errs = append(errs, ctx.Err())
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+)
+
+// QualityClass is the quality rating of an article, as reported by GetQualityClass.
+type QualityClass string
+
+const (
+	// Unassessed is the class of an article that is neither featured nor good.
+	Unassessed QualityClass = ""
+
+	// FeaturedArticle is the class of an article the community rated among its best work.
+	FeaturedArticle QualityClass = "featured"
+
+	// GoodArticle is the class of an article the community rated as meeting its good article criteria.
+	GoodArticle QualityClass = "good"
+)
+
+// qualityCategories maps the maintenance categories marking rated articles to their class.
+var qualityCategories = map[string]QualityClass{
+	categoryPrefix + "Featured articles": FeaturedArticle,
+	categoryPrefix + "Good articles":     GoodArticle,
+}
+
+// GetQualityClass reports whether the article with the given title is a featured or good article using
+// DefaultClient.
+func GetQualityClass(title string) (QualityClass, error) {
+	return DefaultClient.GetQualityClass(context.Background(), title)
+}
+
+// GetQualityClass reports whether the article with the given title is a featured or good article, from its
+// membership in the hidden "Featured articles" and "Good articles" categories, in a single request. It returns
+// Unassessed for other articles. The categories are those of the English Wikipedia; other language editions
+// name theirs differently, so their articles are all reported as Unassessed.
+func (c *Client) GetQualityClass(ctx context.Context, title string) (QualityClass, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "categories"
+	params["clcategories"] = categoryPrefix + "Featured articles|" + categoryPrefix + "Good articles"
+	params["titles"] = title
+	c.followRedirects(params)
+	params["format"] = "json"
+
+	var categoriesResponse categoriesResponse
+
+	err := c.getJSON(ctx, params, &categoriesResponse)
+
+	if err != nil {
+		return Unassessed, err
+	}
+
+	if len(categoriesResponse.Query.Pages) == 0 || categoriesResponse.Query.Pages[0].Missing {
+		return Unassessed, fmt.Errorf("%w: %s", ErrPageNotFound, title)
+	}
+
+	// Featured outranks good, should an article be in both categories
+	class := Unassessed
+
+	for _, category := range categoriesResponse.Query.Pages[0].Categories {
+		switch qualityCategories[category.Title] {
+		case FeaturedArticle:
+			return FeaturedArticle, nil
+		case GoodArticle:
+			class = GoodArticle
+		}
+	}
+
+	return class, nil
+}
@@ -0,0 +1,50 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+)
+
+type randomResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Random []struct {
+			ID    int    `json:"id"`
+			Ns    int    `json:"ns"`
+			Title string `json:"title"`
+		} `json:"random"`
+	} `json:"query"`
+}
+
+// GetRandomArticle fetches a randomly chosen article using DefaultClient.
+func GetRandomArticle() (*Article, error) {
+	return DefaultClient.GetRandomArticle(context.Background())
+}
+
+// GetRandomArticle fetches a randomly chosen article from the main namespace, with the same details as
+// GetArticleDetails. The pick is never served from the client's Cache, so that every call returns a new one.
+func (c *Client) GetRandomArticle(ctx context.Context) (*Article, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["list"] = "random"
+	params["rnnamespace"] = "0"
+	params["rnlimit"] = "1"
+	params["format"] = "json"
+
+	var randomResponse randomResponse
+
+	err := c.uncached().getJSON(ctx, params, &randomResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(randomResponse.Query.Random) == 0 {
+		return nil, errors.New("no random article found")
+	}
+
+	return c.GetArticleDetails(ctx, randomResponse.Query.Random[0].ID)
+}
@@ -0,0 +1,39 @@
+package dwiki
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetRandomArticleBypassesCache(t *testing.T) {
+	var recorder queryRecorder
+
+	client := newTestClient(t, recorder.wrap(serveFixtures(t, [][2]string{
+		{"list=random", "random.json"},
+		{"prop=info|extracts", "extracts_entities.json"},
+	})))
+
+	client.Cache = NewMemoryCache(time.Hour)
+
+	for range 2 {
+		_, err := client.GetRandomArticle(context.Background())
+
+		if err != nil {
+			t.Fatalf("GetRandomArticle() error = %v", err)
+		}
+	}
+
+	picks := 0
+
+	for _, query := range recorder.queries {
+		if query.Get("list") == "random" {
+			picks++
+		}
+	}
+
+	// The pick is made afresh each time, while the article details are cached
+	if picks != 2 || len(recorder.queries) != 3 {
+		t.Errorf("made %d random picks in %d requests, want 2 in 3", picks, len(recorder.queries))
+	}
+}
@@ -0,0 +1,104 @@
+package dwiki
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// clientState holds the mutable state of a Client. It is shared by pointer so that the per-language copies a
+// client makes internally, e.g. in GetSummaryInLanguages, count against the same limits and stats.
+type clientState struct {
+	mu          sync.Mutex
+	nextRequest time.Time
+
+	// inFlight is the semaphore enforcing MaxConcurrency, created with the limit set at the first request.
+	inFlight chan struct{}
+
+	stats statsCounters
+
+	dryRunURLs []string
+
+	// flights holds the requests in flight with ShareRequests, keyed by url.
+	flights map[string]*flight
+}
+
+// stateMu guards the lazy creation of each client's state.
+var stateMu sync.Mutex
+
+func (c *Client) state() *clientState {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	if c.shared == nil {
+		c.shared = &clientState{}
+	}
+
+	return c.shared
+}
+
+// waitForSlot blocks until the client's RequestInterval has passed since the previous request was allowed,
+// or the context is done.
+func (c *Client) waitForSlot(ctx context.Context) error {
+	if c.RequestInterval <= 0 {
+		return nil
+	}
+
+	state := c.state()
+
+	state.mu.Lock()
+
+	now := time.Now()
+	slot := state.nextRequest
+
+	if slot.Before(now) {
+		slot = now
+	}
+
+	state.nextRequest = slot.Add(c.RequestInterval)
+
+	state.mu.Unlock()
+
+	wait := time.Until(slot)
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// acquire blocks until fewer than MaxConcurrency requests are in flight on the client, or the context is done.
+// The returned function releases the slot and must be called once the response has been read.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	if c.MaxConcurrency <= 0 {
+		return func() {}, nil
+	}
+
+	state := c.state()
+
+	state.mu.Lock()
+
+	if state.inFlight == nil {
+		state.inFlight = make(chan struct{}, c.MaxConcurrency)
+	}
+
+	inFlight := state.inFlight
+
+	state.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case inFlight <- struct{}{}:
+		return func() { <-inFlight }, nil
+	}
+}
@@ -0,0 +1,125 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ReaderView is an article laid out for reading, as returned by GetReaderView.
+type ReaderView struct {
+	Title       string `json:"title"`
+	PageID      int    `json:"pageid"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+
+	// Image is the url of the lead image scaled to 320 pixels wide, or empty if the article has none.
+	Image string `json:"image,omitempty"`
+
+	// Paragraphs holds the intro paragraphs, empty if the article has no intro.
+	Paragraphs []string `json:"paragraphs"`
+
+	// Outline lists the headings of the article's sections in page order.
+	Outline []OutlineEntry `json:"outline"`
+}
+
+// OutlineEntry is a section heading in the outline of a ReaderView.
+type OutlineEntry struct {
+	// Title is the heading as plain text.
+	Title string `json:"title"`
+
+	// Level is the heading level as in wikitext: 2 for a top-level section, 3 for a subsection and so on.
+	Level int `json:"level"`
+
+	// Anchor is the fragment linking to the section, e.g. "Early_life" in ".../wiki/Title#Early_life".
+	Anchor string `json:"anchor"`
+}
+
+type readerResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Pageid      int    `json:"pageid"`
+			Title       string `json:"title"`
+			Missing     bool   `json:"missing"`
+			FullURL     string `json:"fullurl"`
+			Extract     string `json:"extract"`
+			Description string `json:"description"`
+			Thumbnail   *struct {
+				Source string `json:"source"`
+			} `json:"thumbnail"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// GetReaderView returns the article with the given page id laid out for reading using DefaultClient.
+func GetReaderView(pageId int) (*ReaderView, error) {
+	return DefaultClient.GetReaderView(context.Background(), pageId)
+}
+
+// GetReaderView returns the article with the given page id laid out for reading: its title, short description,
+// lead image, intro paragraphs and section outline. It makes two requests, a query for the url, intro
+// extract, description and lead image together, and a parse of the section headings. Missing pieces are left
+// empty rather than failing the call: an article without a description, lead image or intro has an empty
+// Description, Image or Paragraphs, and one without sections an empty Outline. It returns ErrPageNotFound when
+// the page doesn't exist.
+func (c *Client) GetReaderView(ctx context.Context, pageId int) (*ReaderView, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info|extracts|description|pageimages"
+	params["inprop"] = "url"
+	params["explaintext"] = ""
+	params["exintro"] = ""
+	params["piprop"] = "thumbnail"
+	params["pithumbsize"] = strconv.Itoa(leadThumbnailWidth)
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var readerResponse readerResponse
+
+	err := c.getJSON(ctx, params, &readerResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(readerResponse.Query.Pages) == 0 || readerResponse.Query.Pages[0].Missing {
+		return nil, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := readerResponse.Query.Pages[0]
+
+	view := &ReaderView{
+		Title:       c.decodeText(page.Title),
+		PageID:      page.Pageid,
+		URL:         page.FullURL,
+		Description: c.decodeText(page.Description),
+		Paragraphs:  splitParagraphs(c.cleanIntro(page.Extract)),
+		Outline:     make([]OutlineEntry, 0),
+	}
+
+	if page.Thumbnail != nil {
+		view.Image = page.Thumbnail.Source
+	}
+
+	parsed, err := c.parsePage(ctx, pageId, "sections", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range parsed.Parse.Sections {
+		level, _ := strconv.Atoi(section.Level)
+
+		view.Outline = append(view.Outline, OutlineEntry{
+			Title:  stripTags(section.Line),
+			Level:  level,
+			Anchor: section.Anchor,
+		})
+	}
+
+	return view, nil
+}
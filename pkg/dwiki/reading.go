@@ -0,0 +1,30 @@
+package dwiki
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultWordsPerMinute is the reading speed used when no positive rate is given to EstimateReadingTime.
+const DefaultWordsPerMinute = 200
+
+// EstimateReadingTime estimates how long it takes to read the given number of words at wordsPerMinute,
+// rounded up to the next whole minute. A non-positive rate uses DefaultWordsPerMinute.
+func EstimateReadingTime(wordCount int, wordsPerMinute int) time.Duration {
+	if wordCount <= 0 {
+		return 0
+	}
+
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+
+	minutes := math.Ceil(float64(wordCount) / float64(wordsPerMinute))
+
+	return time.Duration(minutes) * time.Minute
+}
+
+// ReadingTime estimates how long it takes to read the article at DefaultWordsPerMinute.
+func (a Article) ReadingTime() time.Duration {
+	return EstimateReadingTime(a.WordCount, DefaultWordsPerMinute)
+}
@@ -0,0 +1,50 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRESTNotFound(t *testing.T) {
+	const problem = `{"type": "https://mediawiki.org/wiki/HyperSwitch/errors/not_found", "title": "Not found.", "detail": "Page or revision not found."}`
+
+	tests := []struct {
+		name         string
+		path         string
+		wantNotFound bool
+	}{
+		{"page summary", "/page/summary/Nonexistent_article", true},
+		{"featured feed", "/feed/featured/2001/01/15", false},
+		{"pageviews", "/metrics/pageviews/top/en.wikipedia/all-access/2001/01/15", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(problem))
+			})
+
+			for _, buffered := range []bool{false, true} {
+				if buffered {
+					bufferedClient(client)
+				}
+
+				var v struct{}
+
+				err := client.getRESTJSON(context.Background(), test.path, &v)
+
+				if err == nil {
+					t.Fatal("getRESTJSON() error = nil, want an error")
+				}
+
+				if got := errors.Is(err, ErrPageNotFound); got != test.wantNotFound {
+					t.Errorf("getRESTJSON() error = %v, is ErrPageNotFound = %t, want %t", err, got, test.wantNotFound)
+				}
+			}
+		})
+	}
+}
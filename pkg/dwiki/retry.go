@@ -0,0 +1,204 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRetries is the number of times a throttled request is retried when a Client has no MaxRetries set.
+const DefaultMaxRetries = 2
+
+// defaultRetryWait is used when a throttled response carries no usable Retry-After header.
+const defaultRetryWait = time.Second
+
+func (c *Client) maxRetries() int {
+	if c.MaxRetries == 0 {
+		return DefaultMaxRetries
+	}
+
+	if c.MaxRetries < 0 {
+		return 0
+	}
+
+	return c.MaxRetries
+}
+
+// isThrottled reports whether the status code asks the client to back off and try again later.
+func isThrottled(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter parses a Retry-After header value given either as a number of seconds or as an HTTP date.
+// It returns false if the value is missing or invalid.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+
+	if err != nil {
+		return 0, false
+	}
+
+	wait := date.Sub(now)
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait, true
+}
+
+// do performs a GET request to the given url once the client's concurrency limit allows it. Throttled responses
+// (429 and 503) are retried after the delay given by their Retry-After header, up to the client's retry limit.
+// The slot is freed while waiting, so that a throttled request doesn't hold up the others. The wait is abandoned
+// early when it would outlast the context's deadline. The returned function frees the slot of the response and
+// must be called once it has been read.
+func (c *Client) do(ctx context.Context, rawURL string) (*http.Response, func(), error) {
+	for attempt := 0; ; attempt++ {
+		release, err := c.acquire(ctx)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = c.waitForSlot(ctx)
+
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+
+		// The REST API picks the variant from the header rather than a parameter
+		if c.Variant != "" {
+			req.Header.Set("Accept-Language", c.Variant)
+		}
+
+		start := time.Now()
+
+		c.state().stats.requests.Add(1)
+
+		if attempt > 0 {
+			c.state().stats.retries.Add(1)
+		}
+
+		resp, err := c.httpClient().Do(req)
+
+		c.logRequest(ctx, rawURL, resp, err, time.Since(start), attempt)
+
+		if err != nil {
+			c.state().stats.networkErrors.Add(1)
+			release()
+			return nil, nil, err
+		}
+
+		if !isThrottled(resp.StatusCode) || attempt >= c.maxRetries() {
+			return resp, release, nil
+		}
+
+		wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+		if !ok {
+			wait = defaultRetryWait
+		}
+
+		resp.Body.Close()
+		release()
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return nil, nil, fmt.Errorf("rate limited: retry after %s exceeds the context deadline", wait)
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// failover performs the request to the client's first endpoint, moving on to each of the others in turn when
+// the request fails with a network error or a 5xx status. The last endpoint's outcome is returned as is. As with
+// do, the returned function frees the slot of the response.
+func (c *Client) failover(ctx context.Context, requestURL string) (*http.Response, func(), error) {
+	endpoints, err := c.endpoints()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Every url is built on the first endpoint, so the others only swap its base
+	primary := strings.TrimSuffix(endpoints[0], "/")
+
+	for i, endpoint := range endpoints {
+		rawURL := strings.TrimSuffix(endpoint, "/") + strings.TrimPrefix(requestURL, primary)
+
+		resp, release, err := c.do(ctx, rawURL)
+
+		if i == len(endpoints)-1 || ctx.Err() != nil {
+			return resp, release, err
+		}
+
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, release, nil
+		}
+
+		if err == nil {
+			resp.Body.Close()
+			release()
+		}
+	}
+
+	return nil, nil, errors.New("no endpoints configured")
+}
+
+// logRequest logs the outcome of a request to the client's Logger, if any.
+func (c *Client) logRequest(
+	ctx context.Context, rawURL string, resp *http.Response, err error, latency time.Duration, attempt int,
+) {
+	if c.Logger == nil {
+		return
+	}
+
+	if err != nil {
+		c.Logger.LogAttrs(ctx, slog.LevelError, "wikipedia request failed",
+			slog.String("url", rawURL),
+			slog.Duration("latency", latency),
+			slog.Int("attempt", attempt),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	c.Logger.LogAttrs(ctx, slog.LevelInfo, "wikipedia request",
+		slog.String("url", rawURL),
+		slog.Int("status", resp.StatusCode),
+		slog.Duration("latency", latency),
+		slog.Int("attempt", attempt))
+}
@@ -0,0 +1,55 @@
+package dwiki
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottledRequestFreesSlot(t *testing.T) {
+	const page = `{"batchcomplete": true, "query": {"pages": [{"pageid": 25039021, "ns": 0, "title": "Go (programming language)"}]}}`
+
+	throttled := make(chan struct{})
+
+	var calls atomic.Int32
+
+	// The first request is throttled for a second, every other one is answered at once
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			close(throttled)
+			return
+		}
+
+		serveBody(page)(w, r)
+	})
+
+	client.MaxConcurrency = 1
+	client.MaxRetries = 1
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := client.ArticleExists(context.Background(), "Go")
+		done <- err
+	}()
+
+	<-throttled
+
+	// While the first request waits to be retried, its slot is free for this one
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	exists, err := client.ArticleExists(ctx, "Go (programming language)")
+
+	if err != nil || !exists {
+		t.Errorf("ArticleExists() during the backoff = %t, %v, want true, nil", exists, err)
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("ArticleExists() after the retry error = %v", err)
+	}
+}
@@ -0,0 +1,399 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRevisionCount is the most revisions GetRevisionCount counts before reporting the count as capped.
+const maxRevisionCount = 5000
+
+type revisionsResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Rvcontinue string `json:"rvcontinue"`
+		Continue   string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		Pages []struct {
+			Pageid    int    `json:"pageid"`
+			Title     string `json:"title"`
+			Missing   bool   `json:"missing"`
+			Revisions []struct {
+				Revid     int    `json:"revid"`
+				Parentid  int    `json:"parentid"`
+				User      string `json:"user"`
+				Timestamp string `json:"timestamp"`
+				Comment   string `json:"comment"`
+				Slots     map[string]struct {
+					ContentModel  string `json:"contentmodel"`
+					ContentFormat string `json:"contentformat"`
+					Content       string `json:"content"`
+				} `json:"slots"`
+			} `json:"revisions"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+type compareResponse struct {
+	apiEnvelope
+
+	Compare struct {
+		Fromid    int    `json:"fromid"`
+		Fromrevid int    `json:"fromrevid"`
+		Toid      int    `json:"toid"`
+		Torevid   int    `json:"torevid"`
+		Body      string `json:"body"`
+	} `json:"compare"`
+}
+
+// GetWikitext returns the current wikitext of the article with the given page id using DefaultClient.
+func GetWikitext(pageId int) (string, error) {
+	return DefaultClient.GetWikitext(context.Background(), pageId)
+}
+
+// GetWikitext returns the current wikitext of the article with the given page id. It requests the main slot
+// with rvslots=main, as current MediaWiki versions require, rather than the deprecated revision format.
+func (c *Client) GetWikitext(ctx context.Context, pageId int) (string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "revisions"
+	params["rvprop"] = "content"
+	params["rvslots"] = "main"
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	var revisionsResponse revisionsResponse
+
+	err := c.getJSON(ctx, params, &revisionsResponse)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(revisionsResponse.Query.Pages) == 0 || revisionsResponse.Query.Pages[0].Missing {
+		return "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := revisionsResponse.Query.Pages[0]
+
+	if len(page.Revisions) == 0 {
+		return "", errors.New("no revisions found")
+	}
+
+	main, ok := page.Revisions[0].Slots["main"]
+
+	if !ok {
+		return "", errors.New("no main slot found")
+	}
+
+	return main.Content, nil
+}
+
+// getLastEdits returns the time of the latest revision of each of the given pages, fetching them in as few requests
+// as the API allows. Pages that don't exist are omitted.
+func (c *Client) getLastEdits(ctx context.Context, pageIds []int) (map[int]time.Time, error) {
+	edits := make(map[int]time.Time)
+
+	for start := 0; start < len(pageIds); start += maxPageIds {
+		end := min(start+maxPageIds, len(pageIds))
+
+		ids := make([]string, 0, end-start)
+
+		for _, pageId := range pageIds[start:end] {
+			ids = append(ids, strconv.Itoa(pageId))
+		}
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "revisions"
+		params["rvprop"] = "timestamp"
+		params["pageids"] = strings.Join(ids, "|")
+		params["format"] = "json"
+
+		var revisionsResponse revisionsResponse
+
+		err := c.getJSON(ctx, params, &revisionsResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range revisionsResponse.Query.Pages {
+			if page.Missing || len(page.Revisions) == 0 {
+				continue
+			}
+
+			edited, err := time.Parse(time.RFC3339, page.Revisions[0].Timestamp)
+
+			if err != nil {
+				return nil, err
+			}
+
+			edits[page.Pageid] = edited
+		}
+	}
+
+	return edits, nil
+}
+
+// GetRevisionCount returns the number of revisions of the article with the given page id using DefaultClient.
+func GetRevisionCount(pageId int) (int, bool, error) {
+	return DefaultClient.GetRevisionCount(context.Background(), pageId)
+}
+
+// GetRevisionCount returns the number of revisions, i.e. edits, of the article with the given page id. The API has
+// no cheap way to count them, so the revision ids are paged through 500 at a time. Counting stops at 5000
+// revisions, in which case the returned count is a lower bound and the capped result is true.
+func (c *Client) GetRevisionCount(ctx context.Context, pageId int) (int, bool, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "revisions"
+	params["rvprop"] = "ids"
+	params["rvlimit"] = "max"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	count := 0
+
+	for {
+		var revisionsResponse revisionsResponse
+
+		err := c.getJSON(ctx, params, &revisionsResponse)
+
+		if err != nil {
+			return 0, false, err
+		}
+
+		if len(revisionsResponse.Query.Pages) == 0 || revisionsResponse.Query.Pages[0].Missing {
+			return 0, false, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+		}
+
+		count += len(revisionsResponse.Query.Pages[0].Revisions)
+
+		if revisionsResponse.Continue.Rvcontinue == "" {
+			return count, false, nil
+		}
+
+		if count >= maxRevisionCount {
+			return count, true, nil
+		}
+
+		params["rvcontinue"] = revisionsResponse.Continue.Rvcontinue
+	}
+}
+
+// CompareRevisions returns the HTML diff between two revisions of the article with the given page id using
+// DefaultClient.
+func CompareRevisions(pageId, fromRev, toRev int) (string, error) {
+	return DefaultClient.CompareRevisions(context.Background(), pageId, fromRev, toRev)
+}
+
+// CompareRevisions returns the diff between the revisions fromRev and toRev of the article with the given page id,
+// using action=compare. The diff is the HTML table rows MediaWiki renders on its own diff pages, without the
+// enclosing <table>, and is empty when the revisions have the same content. It returns ErrRevisionNotFound when a
+// revision doesn't exist or belongs to another page.
+func (c *Client) CompareRevisions(ctx context.Context, pageId, fromRev, toRev int) (string, error) {
+	if fromRev < 1 || toRev < 1 {
+		return "", fmt.Errorf("%w: revision ids must be at least 1", ErrRevisionNotFound)
+	}
+
+	params := make(map[string]string)
+
+	params["action"] = "compare"
+	params["fromrev"] = strconv.Itoa(fromRev)
+	params["torev"] = strconv.Itoa(toRev)
+	params["prop"] = "diff|ids"
+	params["format"] = "json"
+
+	var compareResponse compareResponse
+
+	err := c.getJSON(ctx, params, &compareResponse)
+
+	var apiError *APIError
+
+	if errors.As(err, &apiError) && apiError.Code == "nosuchrevid" {
+		return "", fmt.Errorf("%w: %s", ErrRevisionNotFound, apiError.Info)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if compareResponse.Compare.Fromid != pageId {
+		return "", fmt.Errorf("%w: revision %d is not of page %d", ErrRevisionNotFound, fromRev, pageId)
+	}
+
+	if compareResponse.Compare.Toid != pageId {
+		return "", fmt.Errorf("%w: revision %d is not of page %d", ErrRevisionNotFound, toRev, pageId)
+	}
+
+	return compareResponse.Compare.Body, nil
+}
+
+// GetCreationInfo returns when and by whom the article with the given page id was created using DefaultClient.
+func GetCreationInfo(pageId int) (time.Time, string, error) {
+	return DefaultClient.GetCreationInfo(context.Background(), pageId)
+}
+
+// GetCreationInfo returns the time of the first revision of the article with the given page id and the name of the
+// user who made it. The user is empty when the revision's author has been hidden.
+func (c *Client) GetCreationInfo(ctx context.Context, pageId int) (time.Time, string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "revisions"
+	params["rvprop"] = "timestamp|user"
+	params["rvlimit"] = "1"
+	params["rvdir"] = "newer"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var revisionsResponse revisionsResponse
+
+	err := c.getJSON(ctx, params, &revisionsResponse)
+
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	if len(revisionsResponse.Query.Pages) == 0 || revisionsResponse.Query.Pages[0].Missing {
+		return time.Time{}, "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := revisionsResponse.Query.Pages[0]
+
+	if len(page.Revisions) == 0 {
+		return time.Time{}, "", errors.New("no revisions found")
+	}
+
+	created, err := time.Parse(time.RFC3339, page.Revisions[0].Timestamp)
+
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	return created, page.Revisions[0].User, nil
+}
+
+// getRevisionContent returns the id and wikitext of the latest revision of the article with the given page id made
+// at or before the given time, or of the current revision if the time is zero. The id is zero when the article
+// has no revision that old.
+func (c *Client) getRevisionContent(ctx context.Context, pageId int, before time.Time) (int, string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "revisions"
+	params["rvprop"] = "ids|content"
+	params["rvslots"] = "main"
+	params["rvlimit"] = "1"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	if !before.IsZero() {
+		params["rvstart"] = before.UTC().Format(time.RFC3339)
+	}
+
+	var revisionsResponse revisionsResponse
+
+	err := c.getJSON(ctx, params, &revisionsResponse)
+
+	if err != nil {
+		return 0, "", err
+	}
+
+	if len(revisionsResponse.Query.Pages) == 0 || revisionsResponse.Query.Pages[0].Missing {
+		return 0, "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := revisionsResponse.Query.Pages[0]
+
+	if len(page.Revisions) == 0 {
+		return 0, "", nil
+	}
+
+	return page.Revisions[0].Revid, page.Revisions[0].Slots["main"].Content, nil
+}
+
+// GetRecentlyEditedSections returns the sections of the article with the given page id that changed since the
+// given time using DefaultClient.
+func GetRecentlyEditedSections(pageId int, since time.Time) ([]Section, error) {
+	return DefaultClient.GetRecentlyEditedSections(context.Background(), pageId, since)
+}
+
+// GetRecentlyEditedSections returns the sections of the article with the given page id whose wikitext differs
+// between the revision current at since and the current one, with their titles and levels, in page order. The
+// lead section has an empty title. Sections added since are included, and sections removed since follow the
+// others. The Text of each section is left empty. Sections are matched by heading, so a renamed section is
+// reported as removed and added. If the article was created after since, all its sections are returned, and
+// if it hasn't been edited since, none are. It makes two requests, one for each revision.
+func (c *Client) GetRecentlyEditedSections(ctx context.Context, pageId int, since time.Time) ([]Section, error) {
+	currentId, current, err := c.getRevisionContent(ctx, pageId, time.Time{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	baseId, base, err := c.getRevisionContent(ctx, pageId, since)
+
+	if err != nil {
+		return nil, err
+	}
+
+	edited := make([]Section, 0)
+
+	if baseId == currentId {
+		return edited, nil
+	}
+
+	// Key the sections by heading, numbering repeated headings so that each is compared with its counterpart
+	key := func(sections []Section) []string {
+		keys := make([]string, 0, len(sections))
+		seen := make(map[string]int)
+
+		for _, section := range sections {
+			heading := fmt.Sprintf("%d|%s", section.Level, section.Title)
+			seen[heading]++
+			keys = append(keys, fmt.Sprintf("%s|%d", heading, seen[heading]))
+		}
+
+		return keys
+	}
+
+	baseSections := splitSections(base)
+	baseKeys := key(baseSections)
+	baseTexts := make(map[string]string)
+
+	for i, k := range baseKeys {
+		baseTexts[k] = baseSections[i].Text
+	}
+
+	currentSections := splitSections(current)
+
+	for i, k := range key(currentSections) {
+		text, ok := baseTexts[k]
+
+		if baseId == 0 || !ok || text != currentSections[i].Text {
+			edited = append(edited, Section{Title: currentSections[i].Title, Level: currentSections[i].Level})
+		}
+
+		delete(baseTexts, k)
+	}
+
+	for i, k := range baseKeys {
+		if _, ok := baseTexts[k]; ok {
+			edited = append(edited, Section{Title: baseSections[i].Title, Level: baseSections[i].Level})
+		}
+	}
+
+	return edited, nil
+}
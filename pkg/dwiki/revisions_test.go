@@ -0,0 +1,46 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetWikitext(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		pageId  int
+		want    string
+		wantErr error
+	}{
+		{
+			"main slot", "revisions_wikitext.json", 25039021,
+			"{{Short description|Programming language}}\n'''Go''' is a [[High-level programming language|high-level]] [[General-purpose programming language|general-purpose programming language]].",
+			nil,
+		},
+		{"missing page", "revisions_missing.json", 999999999, "", ErrPageNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var recorder queryRecorder
+
+			client := newTestClient(t, recorder.wrap(serveFixture(t, test.fixture)))
+
+			got, err := client.GetWikitext(context.Background(), test.pageId)
+
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("GetWikitext() error = %v, want %v", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("GetWikitext() = %q, want %q", got, test.want)
+			}
+
+			if slots := recorder.last(t).Get("rvslots"); slots != "main" {
+				t.Errorf("rvslots = %q, want %q", slots, "main")
+			}
+		})
+	}
+}
@@ -0,0 +1,1139 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Article describes a Wikipedia article. Every function fills in the title and page id; which of the other
+// fields are set depends on the function that returned it.
+type Article struct {
+	Title  string `json:"title"`
+	PageID int    `json:"pageid"`
+	URL    string `json:"url,omitempty"`
+
+	// DisplayTitle is the title as HTML with the formatting Wikipedia displays it in, e.g. "<i>Homo sapiens</i>".
+	// It is set by the functions that fetch article details. Title keeps the plain form.
+	DisplayTitle string `json:"displaytitle,omitempty"`
+
+	// Length is the size of the article's wikitext in bytes, as set by the functions that fetch article details
+	// and by searches requesting the "size" property with WithProperties.
+	Length int `json:"length,omitempty"`
+
+	// WordCount is the number of words in the article, as reported by the search API.
+	WordCount int `json:"wordcount,omitempty"`
+
+	// Description is the article's one-line short description, e.g. "Programming language", when requested
+	// with WithDescriptions.
+	Description string `json:"description,omitempty"`
+
+	// Aliases lists the titles that redirect to the article, e.g. "JFK" for "John F. Kennedy", when requested
+	// with WithAliases.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Snippet is an excerpt of the article text around the search match, as shown in Wikipedia's own results.
+	Snippet string `json:"snippet,omitempty"`
+
+	// LastEdited is the time of the article's latest edit as an RFC 3339 timestamp, when the "timestamp" search
+	// property is requested with WithProperties.
+	LastEdited string `json:"lastedited,omitempty"`
+
+	// RedirectTitle and SectionTitle are the title of the redirect and of the section the search matched, if any,
+	// when the "redirecttitle" and "sectiontitle" search properties are requested with WithProperties.
+	RedirectTitle string `json:"redirecttitle,omitempty"`
+	SectionTitle  string `json:"sectiontitle,omitempty"`
+
+	// Extract is the full, untruncated intro text of the article.
+	Extract string `json:"extract,omitempty"`
+
+	// Preview is the opening of the extract truncated at a word boundary to the client's summary length,
+	// so that it can be shown first and expanded to Extract without another request.
+	Preview string `json:"preview,omitempty"`
+
+	// Views is the number of times the article was viewed, as set by GetMostViewed.
+	Views int `json:"views,omitempty"`
+
+	// Categories lists the article's visible categories, without the "Category:" prefix.
+	Categories []string `json:"categories,omitempty"`
+}
+
+type searchOptions struct {
+	limit      int
+	dedupe     bool
+	titlesOnly bool
+	sortTitles bool
+	profile    string
+	describe   bool
+	recent     bool
+	aliases    bool
+	since      time.Time
+	properties []string
+	expand     bool
+
+	// highlight wraps the matched terms of snippets in before and after, keeping the snippets as HTML when
+	// highlightHTML is set. highlightTag is the element given to WithHTMLHighlight.
+	highlight       bool
+	highlightHTML   bool
+	highlightTag    string
+	highlightBefore string
+	highlightAfter  string
+}
+
+// Option configures a search.
+type Option func(*searchOptions)
+
+// WithLimit sets the maximum number of results returned, overriding the client's SearchLimit.
+func WithLimit(limit int) Option {
+	return func(o *searchOptions) {
+		o.limit = limit
+	}
+}
+
+// WithDeduplication resolves the results through redirects and drops any result that points to the same page
+// as a higher-ranked one, or whose title matches a higher-ranked one's title or redirect title. This costs one
+// extra request.
+func WithDeduplication() Option {
+	return func(o *searchOptions) {
+		o.dedupe = true
+	}
+}
+
+// WithTitlesOnly matches the topic against article titles only, using srwhat=title. Wikis that have disabled
+// title search, including Wikipedia's CirrusSearch, reject it; the search is then retried with every word of
+// the topic wrapped in the intitle: operator, which has the same effect.
+func WithTitlesOnly() Option {
+	return func(o *searchOptions) {
+		o.titlesOnly = true
+	}
+}
+
+// WithAlphabeticalOrder sorts the results by title, ignoring case, instead of by relevance. The most relevant
+// results are still the ones returned; only their order changes.
+func WithAlphabeticalOrder() Option {
+	return func(o *searchOptions) {
+		o.sortTitles = true
+	}
+}
+
+// WithDescriptions fills in the Description of every result. The descriptions are fetched together after the
+// search, costing one extra request per 50 results.
+func WithDescriptions() Option {
+	return func(o *searchOptions) {
+		o.describe = true
+	}
+}
+
+// WithDisambiguationExpansion replaces the results, when every one of them is a disambiguation page, with the
+// articles the first of them lists, so that there is still something to pick from. The candidates are sorted by
+// title rather than by relevance. They are only expanded once: candidates that are disambiguation pages
+// themselves are dropped rather than expanded in turn, so the expansion can't loop. It costs a request for the
+// page's links and one more per 50 candidates.
+func WithDisambiguationExpansion() Option {
+	return func(o *searchOptions) {
+		o.expand = true
+	}
+}
+
+// WithHighlight wraps the terms the search matched in the Snippet of every result in before and after instead of
+// stripping the highlighting, e.g. "**" and "**" for Markdown bold. The snippet is otherwise plain text, as
+// without the option. Use WithHTMLHighlight to embed snippets in a web page.
+func WithHighlight(before, after string) Option {
+	return func(o *searchOptions) {
+		o.highlight = true
+		o.highlightHTML = false
+		o.highlightBefore = before
+		o.highlightAfter = after
+	}
+}
+
+// WithHTMLHighlight keeps the Snippet of every result as HTML, with characters such as "<" and "&" escaped so that
+// it is safe to embed in a web page, and wraps the terms the search matched in the given element, e.g. "mark" for
+// <mark>term</mark>. The snippets come from the snippet result property, which is requested by default. The tag
+// must be a bare element name, without attributes or brackets; any other tag fails the search.
+func WithHTMLHighlight(tag string) Option {
+	return func(o *searchOptions) {
+		o.highlight = true
+		o.highlightHTML = true
+		o.highlightTag = tag
+		o.highlightBefore = "<" + tag + ">"
+		o.highlightAfter = "</" + tag + ">"
+	}
+}
+
+// WithAliases fills in the Aliases of every result with the titles that redirect to it. The redirects are fetched
+// together after the search, costing at least one extra request per 50 results.
+func WithAliases() Option {
+	return func(o *searchOptions) {
+		o.aliases = true
+	}
+}
+
+// WithRecentEdits orders the results by when they were last edited, most recent first, using srsort=last_edit_desc.
+// If since is not zero, results last edited before it are dropped. The edit times are looked up after the
+// search, costing one extra request per 50 results. Where the search engine doesn't support srsort, the most
+// relevant results are fetched instead and ordered by their edit times, so they may not be the most recent
+// matches overall.
+func WithRecentEdits(since time.Time) Option {
+	return func(o *searchOptions) {
+		o.recent = true
+		o.since = since
+	}
+}
+
+// defaultSearchProperties are the result properties a search requests unless WithProperties is given.
+var defaultSearchProperties = []string{"wordcount", "snippet", "categorysnippet"}
+
+// searchProperties lists the result properties accepted by WithProperties.
+var searchProperties = map[string]bool{
+	"size":            true,
+	"wordcount":       true,
+	"timestamp":       true,
+	"snippet":         true,
+	"titlesnippet":    true,
+	"redirecttitle":   true,
+	"redirectsnippet": true,
+	"sectiontitle":    true,
+	"sectionsnippet":  true,
+	"isfilematch":     true,
+	"categorysnippet": true,
+	"extensiondata":   true,
+}
+
+// WithProperties sets the result properties the search requests through the srprop parameter, replacing the
+// default of "wordcount", "snippet" and "categorysnippet", e.g. "size" and "timestamp" to fill in Length and
+// LastEdited. Fields of properties that aren't requested are left empty, and calling it without properties
+// requests none, leaving just the titles and page ids. An unknown property fails the search.
+func WithProperties(properties ...string) Option {
+	return func(o *searchOptions) {
+		o.properties = append(make([]string, 0, len(properties)), properties...)
+	}
+}
+
+// rankingProfiles lists the query-independent ranking profiles accepted by WithRankingProfile.
+var rankingProfiles = map[string]bool{
+	"classic":              true,
+	"classic_noboostlinks": true,
+	"empty":                true,
+	"engine_autoselect":    true,
+	"popular_inclinks":     true,
+	"popular_inclinks_pv":  true,
+	"wsum_inclinks":        true,
+	"wsum_inclinks_pv":     true,
+}
+
+// WithRankingProfile sets the query-independent ranking profile used to order the results, e.g. "classic" or
+// "popular_inclinks" to favor well-linked articles, through the srqiprofile parameter. By default the search
+// engine picks the profile, as with "engine_autoselect". An unknown profile fails the search.
+func WithRankingProfile(profile string) Option {
+	return func(o *searchOptions) {
+		o.profile = profile
+	}
+}
+
+// elementNamePattern matches a bare HTML element name, such as "mark" or "my-highlight".
+var elementNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+
+// validate reports an error for option values the API would reject.
+func (o searchOptions) validate() error {
+	if o.profile != "" && !rankingProfiles[o.profile] {
+		return fmt.Errorf("unknown ranking profile %q", o.profile)
+	}
+
+	for _, property := range o.properties {
+		if !searchProperties[property] {
+			return fmt.Errorf("unknown search property %q", property)
+		}
+	}
+
+	if o.highlightHTML && !elementNamePattern.MatchString(o.highlightTag) {
+		return fmt.Errorf("invalid highlight element %q", o.highlightTag)
+	}
+
+	return nil
+}
+
+func (c *Client) searchOptions(opts []Option) searchOptions {
+	options := searchOptions{limit: c.searchLimit()}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.limit <= 0 {
+		options.limit = c.searchLimit()
+	}
+
+	return options
+}
+
+type redirectsResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Redirects []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"redirects"`
+		Pages []struct {
+			Pageid int    `json:"pageid"`
+			Title  string `json:"title"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// Search searches for articles matching the given topic using DefaultClient.
+func Search(topic string, opts ...Option) ([]Article, error) {
+	return DefaultClient.Search(context.Background(), topic, opts...)
+}
+
+// Search searches for articles matching the given topic and returns them in rank order, with disambiguation
+// pages removed. At most the client's SearchLimit results are returned unless overridden with WithLimit.
+// If only the disambiguation lookup fails, the results are returned unfiltered and the failure is logged.
+//
+// The topic is sent as is, so it may use the search engine's advanced syntax: operators such as intitle:,
+// insource:, incategory: and prefix:, quoted phrases, and words excluded with a leading "-", e.g.
+// `intitle:golang -insource:"python"`.
+func (c *Client) Search(ctx context.Context, topic string, opts ...Option) ([]Article, error) {
+	options := c.searchOptions(opts)
+
+	// Ask for extra results so that the list is still full after disambiguation pages are removed
+	articles, _, err := c.searchPage(ctx, topic, options.limit*2, 0, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.finishSearch(ctx, articles, options)
+}
+
+// BuildSearchURL returns the API url Search would call for the given topic and options using DefaultClient.
+func BuildSearchURL(topic string, opts ...Option) (string, error) {
+	return DefaultClient.BuildSearchURL(topic, opts...)
+}
+
+// BuildSearchURL returns the API url Search would call for the given topic and options, without making the
+// request. Follow-up requests, such as the disambiguation lookup, are not included.
+func (c *Client) BuildSearchURL(topic string, opts ...Option) (string, error) {
+	options := c.searchOptions(opts)
+
+	err := options.validate()
+
+	if err != nil {
+		return "", err
+	}
+
+	return c.requestURL(searchRequestParams(topic, options.limit*2, 0, options))
+}
+
+// SearchAll searches for up to max articles matching the given topic using DefaultClient.
+func SearchAll(topic string, max int, opts ...Option) ([]Article, error) {
+	return DefaultClient.SearchAll(context.Background(), topic, max, opts...)
+}
+
+// SearchAll searches for articles matching the given topic like Search, but follows the search continuation
+// across as many requests as needed to collect max articles or exhaust the results. The context is checked
+// between pages, and each page request waits for the client's RequestInterval like any other.
+func (c *Client) SearchAll(ctx context.Context, topic string, max int, opts ...Option) ([]Article, error) {
+	if max < 1 {
+		return nil, errors.New("max must be at least 1")
+	}
+
+	options := c.searchOptions(opts)
+	options.limit = max
+
+	articles := make([]Article, 0, max)
+	offset := 0
+
+	for len(articles) < max {
+		err := ctx.Err()
+
+		if err != nil {
+			return nil, err
+		}
+
+		page, nextOffset, err := c.searchPage(ctx, topic, min(max-len(articles), maxSearchLimit), offset, options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		articles = append(articles, page...)
+
+		if nextOffset == 0 {
+			break
+		}
+
+		offset = nextOffset
+	}
+
+	return c.finishSearch(ctx, articles, options)
+}
+
+// maxSearchLimit is the most search results the API returns in a single request.
+const maxSearchLimit = 500
+
+// searchPage requests one page of search results starting at offset and removes the disambiguation pages.
+// It returns the offset of the next page, or zero if there are no more results.
+func (c *Client) searchPage(
+	ctx context.Context, topic string, limit int, offset int, options searchOptions,
+) ([]Article, int, error) {
+	err := options.validate()
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	params := searchRequestParams(topic, limit, offset, options)
+
+	// Call the API
+	var searchResponse searchResponse
+
+	err = c.getJSON(ctx, params, &searchResponse)
+
+	// Fall back to the intitle: operator where title search is disabled
+	var apiError *APIError
+
+	if options.titlesOnly && errors.As(err, &apiError) && apiError.Code == "search-title-disabled" {
+		options.titlesOnly = false
+
+		params = searchRequestParams(intitleQuery(topic), limit, offset, options)
+
+		err = c.getJSON(ctx, params, &searchResponse)
+	}
+
+	// Fall back to relevance order where sorting by last edit is unsupported; finishSearch orders the results
+	if options.recent && errors.As(err, &apiError) && apiError.Code == "badvalue" {
+		delete(params, "srsort")
+
+		err = c.getJSON(ctx, params, &searchResponse)
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	articles := make([]Article, 0, len(searchResponse.Query.Search))
+
+	if len(searchResponse.Query.Search) == 0 {
+		return articles, 0, nil
+	}
+
+	// Get the page props for the search results to eliminate disambiguation pages
+	pageIds := make([]int, 0, len(searchResponse.Query.Search))
+
+	for _, result := range searchResponse.Query.Search {
+		pageIds = append(pageIds, result.Pageid)
+	}
+
+	disambiguations, err := c.getDisambiguations(ctx, pageIds)
+
+	// The filtering is best-effort: the search results are still valid if only the lookup failed
+	filter := err == nil
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+
+		if c.Logger != nil {
+			c.Logger.LogAttrs(ctx, slog.LevelWarn, "wikipedia disambiguation lookup failed, results are unfiltered",
+				slog.String("error", err.Error()))
+		}
+	}
+
+	expand := 0
+
+	for _, result := range searchResponse.Query.Search {
+		// Skip disambiguation pages and pages that no longer exist
+		isDisambiguation, ok := disambiguations[result.Pageid]
+
+		if filter && isDisambiguation && expand == 0 {
+			expand = result.Pageid
+		}
+
+		if filter && (!ok || isDisambiguation) {
+			continue
+		}
+
+		articles = append(articles, Article{
+			Title:         c.decodeText(result.Title),
+			PageID:        result.Pageid,
+			Length:        result.Size,
+			WordCount:     result.Wordcount,
+			Snippet:       c.snippet(result.Snippet, options),
+			LastEdited:    result.Timestamp,
+			RedirectTitle: c.decodeText(result.RedirectTitle),
+			SectionTitle:  c.decodeText(result.SectionTitle),
+		})
+	}
+
+	// Only the first page of results is expanded, and the candidates replace the rest of the results
+	if options.expand && offset == 0 && len(articles) == 0 && expand != 0 {
+		articles, err = c.expandDisambiguation(ctx, expand)
+		return articles, 0, err
+	}
+
+	return articles, searchResponse.Continue.Sroffset, nil
+}
+
+// expandDisambiguation returns the articles the disambiguation page with the given id lists, without those that
+// are disambiguation pages themselves.
+func (c *Client) expandDisambiguation(ctx context.Context, pageId int) ([]Article, error) {
+	options, err := c.getLinkedArticles(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Article, 0, len(options))
+
+	for start := 0; start < len(options); start += maxPageIds {
+		end := min(start+maxPageIds, len(options))
+
+		pageIds := make([]int, 0, end-start)
+
+		for _, option := range options[start:end] {
+			pageIds = append(pageIds, option.PageID)
+		}
+
+		disambiguations, err := c.getDisambiguations(ctx, pageIds)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, option := range options[start:end] {
+			if !disambiguations[option.PageID] {
+				candidates = append(candidates, option)
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+// snippet returns a result's snippet with its highlighting stripped, or converted as the options ask.
+func (c *Client) snippet(snippet string, options searchOptions) string {
+	if !options.highlight {
+		return c.decodeText(stripSearchMatches(snippet))
+	}
+
+	snippet = highlightSearchMatches(snippet, options.highlightBefore, options.highlightAfter)
+
+	if options.highlightHTML {
+		return snippet
+	}
+
+	return c.decodeText(snippet)
+}
+
+// finishSearch applies the optional post-processing to the search results and caps them to the limit.
+func (c *Client) finishSearch(ctx context.Context, articles []Article, options searchOptions) ([]Article, error) {
+	var err error
+
+	if options.dedupe {
+		articles, err = c.dedupe(ctx, articles)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.recent {
+		articles, err = c.byRecentEdits(ctx, articles, options.since)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(articles) > options.limit {
+		articles = articles[:options.limit]
+	}
+
+	if options.describe {
+		err = c.describe(ctx, articles)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.aliases {
+		err = c.addAliases(ctx, articles)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.sortTitles {
+		sort.SliceStable(articles, func(i, j int) bool {
+			return strings.ToLower(articles[i].Title) < strings.ToLower(articles[j].Title)
+		})
+	}
+
+	return articles, nil
+}
+
+// searchRequestParams returns the query parameters of a search page request with the given options applied.
+func searchRequestParams(topic string, limit int, offset int, options searchOptions) map[string]string {
+	params := searchParams(topic, limit)
+
+	params["srprop"] = strings.Join(defaultSearchProperties, "|")
+
+	if options.properties != nil {
+		params["srprop"] = strings.Join(options.properties, "|")
+	}
+
+	if offset > 0 {
+		params["sroffset"] = strconv.Itoa(offset)
+	}
+
+	if options.titlesOnly {
+		params["srwhat"] = "title"
+	}
+
+	if options.profile != "" {
+		params["srqiprofile"] = options.profile
+	}
+
+	if options.recent {
+		params["srsort"] = "last_edit_desc"
+	}
+
+	return params
+}
+
+// searchOperators lists the search keywords that intitleQuery leaves alone, so that a topic already using them,
+// e.g. "insource:golang", keeps its meaning.
+var searchOperators = []string{
+	"intitle:", "incategory:", "deepcat:", "insource:", "prefix:", "hastemplate:", "linksto:", "morelike:",
+	"inlanguage:", "articletopic:", "subpageof:",
+}
+
+// intitleQuery restricts every term of the topic to article titles with the intitle: operator. A quoted phrase is
+// one term, so it is matched as a phrase rather than word by word. Terms that are already search operators are kept
+// as they are so that advanced queries still work.
+func intitleQuery(topic string) string {
+	terms := searchTerms(topic)
+
+	for i, term := range terms {
+		if !isSearchOperator(term) {
+			terms[i] = "intitle:" + term
+		}
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// searchTerms splits a query into its whitespace-separated terms, keeping each quoted phrase, along with any
+// operator or negation right before it as in intitle:"go gopher", in a single term. An unterminated quote runs to
+// the end of the query.
+func searchTerms(query string) []string {
+	terms := make([]string, 0)
+
+	var term strings.Builder
+	quoted := false
+
+	for _, r := range query {
+		if r == '"' {
+			quoted = !quoted
+		}
+
+		if !quoted && unicode.IsSpace(r) {
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+
+			continue
+		}
+
+		term.WriteRune(r)
+	}
+
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+
+	return terms
+}
+
+// isSearchOperator reports whether the word, possibly negated with a leading "-" or "!", starts with one of
+// searchOperators.
+func isSearchOperator(word string) bool {
+	word = strings.ToLower(strings.TrimLeft(word, "-!"))
+
+	for _, operator := range searchOperators {
+		if strings.HasPrefix(word, operator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// searchParams returns the query parameters of a full-text search for the given topic.
+func searchParams(topic string, limit int) map[string]string {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["list"] = "search"
+	params["srsearch"] = topic
+	params["format"] = "json"
+	params["srlimit"] = strconv.Itoa(limit)
+
+	return params
+}
+
+// SearchTitles returns the titles of up to limit articles matching the given topic using DefaultClient.
+func SearchTitles(topic string, limit int) ([]string, error) {
+	return DefaultClient.SearchTitles(context.Background(), topic, limit)
+}
+
+// SearchTitles returns the titles of up to limit articles matching the given topic in rank order. It makes a
+// single search request and, unlike Search, does not filter out disambiguation pages.
+func (c *Client) SearchTitles(ctx context.Context, topic string, limit int) ([]string, error) {
+	if limit < 1 {
+		return nil, errors.New("limit must be at least 1")
+	}
+
+	params := searchParams(topic, limit)
+
+	// Only the titles are needed, so skip the optional result properties
+	params["srprop"] = ""
+
+	var searchResponse searchResponse
+
+	err := c.getJSON(ctx, params, &searchResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0, len(searchResponse.Query.Search))
+
+	for _, result := range searchResponse.Query.Search {
+		titles = append(titles, c.decodeText(result.Title))
+	}
+
+	return titles, nil
+}
+
+// dedupe resolves the articles through redirects in one request and keeps only the first, highest-ranked,
+// article pointing to each page. An article is also dropped when its title, or the redirect title it was matched
+// through, is the title or redirect title of one kept before it, e.g. a redirect listed next to its target.
+func (c *Client) dedupe(ctx context.Context, articles []Article) ([]Article, error) {
+	if len(articles) == 0 {
+		return articles, nil
+	}
+
+	ids := make([]string, 0, len(articles))
+
+	for _, article := range articles {
+		ids = append(ids, strconv.Itoa(article.PageID))
+	}
+
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["redirects"] = ""
+	params["format"] = "json"
+	params["pageids"] = strings.Join(ids, "|")
+
+	var redirectsResponse redirectsResponse
+
+	err := c.getJSON(ctx, params, &redirectsResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The articles' titles are decoded, so the API's are too before they are compared
+	titleIds := make(map[string]int)
+
+	for _, page := range redirectsResponse.Query.Pages {
+		titleIds[c.decodeText(page.Title)] = page.Pageid
+	}
+
+	targets := make(map[string]string)
+
+	for _, redirect := range redirectsResponse.Query.Redirects {
+		targets[c.decodeText(redirect.From)] = c.decodeText(redirect.To)
+	}
+
+	seenIds := make(map[int]bool)
+	seenTitles := make(map[string]bool)
+	deduped := make([]Article, 0, len(articles))
+
+	for _, article := range articles {
+		resolvedId := article.PageID
+		titles := []string{article.Title}
+
+		if target, ok := targets[article.Title]; ok {
+			titles = append(titles, target)
+
+			if id, ok := titleIds[target]; ok {
+				resolvedId = id
+			}
+		}
+
+		if article.RedirectTitle != "" {
+			titles = append(titles, article.RedirectTitle)
+		}
+
+		duplicate := seenIds[resolvedId]
+
+		for _, title := range titles {
+			duplicate = duplicate || seenTitles[title]
+		}
+
+		if duplicate {
+			continue
+		}
+
+		seenIds[resolvedId] = true
+
+		for _, title := range titles {
+			seenTitles[title] = true
+		}
+
+		deduped = append(deduped, article)
+	}
+
+	return deduped, nil
+}
+
+type aliasesResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Rdcontinue string `json:"rdcontinue"`
+		Continue   string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		Pages []struct {
+			Pageid    int    `json:"pageid"`
+			Title     string `json:"title"`
+			Redirects []struct {
+				Pageid int    `json:"pageid"`
+				Ns     int    `json:"ns"`
+				Title  string `json:"title"`
+			} `json:"redirects"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// addAliases sets the aliases of each article to the titles of the articles redirecting to it, following
+// continuation until all have been read.
+func (c *Client) addAliases(ctx context.Context, articles []Article) error {
+	for start := 0; start < len(articles); start += maxPageIds {
+		end := min(start+maxPageIds, len(articles))
+
+		ids := make([]string, 0, end-start)
+
+		for _, article := range articles[start:end] {
+			ids = append(ids, strconv.Itoa(article.PageID))
+		}
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "redirects"
+		params["rdprop"] = "pageid|title"
+		params["rdnamespace"] = "0"
+		params["rdlimit"] = "max"
+		params["pageids"] = strings.Join(ids, "|")
+		params["format"] = "json"
+
+		aliases := make(map[int][]string)
+
+		for {
+			var aliasesResponse aliasesResponse
+
+			err := c.getJSON(ctx, params, &aliasesResponse)
+
+			if err != nil {
+				return err
+			}
+
+			for _, page := range aliasesResponse.Query.Pages {
+				for _, redirect := range page.Redirects {
+					aliases[page.Pageid] = append(aliases[page.Pageid], c.decodeText(redirect.Title))
+				}
+			}
+
+			if aliasesResponse.Continue.Rdcontinue == "" {
+				break
+			}
+
+			params["rdcontinue"] = aliasesResponse.Continue.Rdcontinue
+		}
+
+		for i := start; i < end; i++ {
+			articles[i].Aliases = aliases[articles[i].PageID]
+		}
+	}
+
+	return nil
+}
+
+// byRecentEdits orders the articles by their last edit, most recent first, dropping those last edited before since.
+func (c *Client) byRecentEdits(ctx context.Context, articles []Article, since time.Time) ([]Article, error) {
+	pageIds := make([]int, 0, len(articles))
+
+	for _, article := range articles {
+		pageIds = append(pageIds, article.PageID)
+	}
+
+	edits, err := c.getLastEdits(ctx, pageIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	recent := make([]Article, 0, len(articles))
+
+	for _, article := range articles {
+		if !since.IsZero() && edits[article.PageID].Before(since) {
+			continue
+		}
+
+		recent = append(recent, article)
+	}
+
+	sort.SliceStable(recent, func(i, j int) bool {
+		return edits[recent[i].PageID].After(edits[recent[j].PageID])
+	})
+
+	return recent, nil
+}
+
+// maxPageIds is the most page ids the API accepts in a single request.
+const maxPageIds = 50
+
+type descriptionsResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Pageid      int    `json:"pageid"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// describe sets the short description of each article, fetching them in as few requests as the API allows.
+func (c *Client) describe(ctx context.Context, articles []Article) error {
+	for start := 0; start < len(articles); start += maxPageIds {
+		end := min(start+maxPageIds, len(articles))
+
+		ids := make([]string, 0, end-start)
+
+		for _, article := range articles[start:end] {
+			ids = append(ids, strconv.Itoa(article.PageID))
+		}
+
+		params := make(map[string]string)
+
+		params["action"] = "query"
+		params["prop"] = "description"
+		params["pageids"] = strings.Join(ids, "|")
+		params["format"] = "json"
+
+		var descriptionsResponse descriptionsResponse
+
+		err := c.getJSON(ctx, params, &descriptionsResponse)
+
+		if err != nil {
+			return err
+		}
+
+		descriptions := make(map[int]string)
+
+		for _, page := range descriptionsResponse.Query.Pages {
+			descriptions[page.Pageid] = page.Description
+		}
+
+		for i := start; i < end; i++ {
+			articles[i].Description = c.decodeText(descriptions[articles[i].PageID])
+		}
+	}
+
+	return nil
+}
+
+type prefixSearchResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Psoffset int    `json:"psoffset"`
+		Continue string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		PrefixSearch []struct {
+			Ns     int    `json:"ns"`
+			Title  string `json:"title"`
+			Pageid int    `json:"pageid"`
+		} `json:"prefixsearch"`
+	} `json:"query"`
+}
+
+// PrefixSearch returns up to limit articles whose titles start with the given prefix using DefaultClient.
+func PrefixSearch(prefix string, limit int) ([]Article, error) {
+	return DefaultClient.PrefixSearch(context.Background(), prefix, limit)
+}
+
+// PrefixSearch returns up to limit articles whose titles start with the given prefix, using the
+// list=prefixsearch API. Unlike a full-text search the results are tuned for title completion.
+func (c *Client) PrefixSearch(ctx context.Context, prefix string, limit int) ([]Article, error) {
+	if limit < 1 {
+		return nil, errors.New("limit must be at least 1")
+	}
+
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["list"] = "prefixsearch"
+	params["pssearch"] = prefix
+	params["pslimit"] = strconv.Itoa(limit)
+	params["format"] = "json"
+
+	var prefixSearchResponse prefixSearchResponse
+
+	err := c.getJSON(ctx, params, &prefixSearchResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]Article, 0, len(prefixSearchResponse.Query.PrefixSearch))
+
+	for _, result := range prefixSearchResponse.Query.PrefixSearch {
+		articles = append(articles, Article{Title: c.decodeText(result.Title), PageID: result.Pageid})
+	}
+
+	return articles, nil
+}
+
+// MoreLike returns up to limit articles similar in content to the one with the given title using DefaultClient.
+func MoreLike(title string, limit int) ([]Article, error) {
+	return DefaultClient.MoreLike(context.Background(), title, limit)
+}
+
+// MoreLike returns up to limit articles similar in content to the one with the given title, in rank order, using
+// the search engine's morelike: operator, which compares the articles' text rather than their links. The operator
+// is specific to the CirrusSearch backend that Wikipedia runs; other wikis may reject it or treat it as plain
+// words. The result is empty, not an error, when there are no similar articles, as is often the case for very
+// short or new articles, or when the title doesn't exist.
+func (c *Client) MoreLike(ctx context.Context, title string, limit int) ([]Article, error) {
+	// A "|" would separate several titles to compare against
+	title = strings.ReplaceAll(title, "|", "")
+
+	return c.Search(ctx, "morelike:"+title, WithLimit(limit))
+}
+
+// SearchResult is a page of search results as the API returned it, as returned by SearchRaw.
+type SearchResult struct {
+	// TotalHits is the number of pages the search engine reports as matching.
+	TotalHits int `json:"totalhits"`
+
+	// Suggestion is the search engine's spelling correction of the topic, if it has one.
+	Suggestion string `json:"suggestion,omitempty"`
+
+	// NextOffset is the offset of the next page of results, or zero if there are no more.
+	NextOffset int `json:"nextoffset,omitempty"`
+
+	Hits []SearchHit `json:"hits"`
+}
+
+// SearchHit is a single search result as the API returned it. The snippets are HTML, with the matched words
+// wrapped in <span class="searchmatch">, and are only set for the properties that were requested, by default
+// wordcount, snippet and categorysnippet.
+type SearchHit struct {
+	Ns              int    `json:"ns"`
+	Title           string `json:"title"`
+	PageID          int    `json:"pageid"`
+	Size            int    `json:"size,omitempty"`
+	WordCount       int    `json:"wordcount,omitempty"`
+	Timestamp       string `json:"timestamp,omitempty"`
+	Snippet         string `json:"snippet,omitempty"`
+	TitleSnippet    string `json:"titlesnippet,omitempty"`
+	CategorySnippet string `json:"categorysnippet,omitempty"`
+	RedirectTitle   string `json:"redirecttitle,omitempty"`
+	RedirectSnippet string `json:"redirectsnippet,omitempty"`
+	SectionTitle    string `json:"sectiontitle,omitempty"`
+	SectionSnippet  string `json:"sectionsnippet,omitempty"`
+	IsFileMatch     bool   `json:"isfilematch,omitempty"`
+}
+
+// SearchRaw searches for the topic and returns the response as the API returned it using DefaultClient.
+func SearchRaw(topic string, opts ...Option) (*SearchResult, error) {
+	return DefaultClient.SearchRaw(context.Background(), topic, opts...)
+}
+
+// SearchRaw sends a search request for the topic like Search and returns every field of the response, as an
+// escape hatch for callers needing what Article doesn't expose. The results are left as the API returned them:
+// disambiguation pages are kept, nothing is decoded or stripped, and there is no fallback when the wiki rejects
+// an option. Since no results are removed, only the requested limit is fetched, rather than the twice as many
+// Search asks for, so its url differs from the one BuildSearchURL returns in the srlimit parameter. The options that shape the request apply, WithLimit, WithTitlesOnly, WithRankingProfile,
+// WithRecentEdits and WithProperties, while those that post-process the results are ignored.
+func (c *Client) SearchRaw(ctx context.Context, topic string, opts ...Option) (*SearchResult, error) {
+	options := c.searchOptions(opts)
+
+	err := options.validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var searchResponse searchResponse
+
+	err = c.getJSON(ctx, searchRequestParams(topic, options.limit, 0, options), &searchResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{
+		TotalHits:  searchResponse.Query.Searchinfo.Totalhits,
+		Suggestion: searchResponse.Query.Searchinfo.Suggestion,
+		NextOffset: searchResponse.Continue.Sroffset,
+		Hits:       make([]SearchHit, 0, len(searchResponse.Query.Search)),
+	}
+
+	for _, hit := range searchResponse.Query.Search {
+		result.Hits = append(result.Hits, SearchHit{
+			Ns:              hit.Ns,
+			Title:           hit.Title,
+			PageID:          hit.Pageid,
+			Size:            hit.Size,
+			WordCount:       hit.Wordcount,
+			Timestamp:       hit.Timestamp,
+			Snippet:         hit.Snippet,
+			TitleSnippet:    hit.TitleSnippet,
+			CategorySnippet: hit.CategorySnippet,
+			RedirectTitle:   hit.RedirectTitle,
+			RedirectSnippet: hit.RedirectSnippet,
+			SectionTitle:    hit.SectionTitle,
+			SectionSnippet:  hit.SectionSnippet,
+			IsFileMatch:     hit.IsFileMatch,
+		})
+	}
+
+	return result, nil
+}
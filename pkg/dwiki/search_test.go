@@ -0,0 +1,176 @@
+package dwiki
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSearchSendsTopicVerbatim(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		want  string
+	}{
+		{"phrase", "machine learning", "machine learning"},
+		{"quoted phrase", `"machine learning"`, `"machine learning"`},
+		{"intitle operator", "intitle:golang", "intitle:golang"},
+		{"prefix operator", "prefix:Go", "prefix:Go"},
+		{"insource phrase", `insource:"fmt.Println" -intitle:tutorial`, `insource:"fmt.Println" -intitle:tutorial`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var recorder queryRecorder
+
+			client := newTestClient(t, recorder.wrap(serveFixtures(t, [][2]string{
+				{"list=search", "search_machine_learning.json"},
+				{"prop=pageprops", "pageprops_machine_learning.json"},
+			})))
+
+			articles, err := client.Search(context.Background(), test.topic)
+
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			if len(articles) != 2 {
+				t.Fatalf("Search() returned %d articles, want 2", len(articles))
+			}
+
+			if got := recorder.queries[0].Get("srsearch"); got != test.want {
+				t.Errorf("srsearch = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSearchDecodesEntities(t *testing.T) {
+	tests := []struct {
+		name        string
+		rawText     bool
+		wantTitle   string
+		wantSnippet string
+	}{
+		{"decoded", false, "AT&T 'Death Star'", `The "Death Star" logo of AT&T`},
+		{"raw text", true, "AT&amp;T &#39;Death Star&#39;", "The &quot;Death Star&quot; logo of AT&amp;T"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveFixtures(t, [][2]string{
+				{"list=search", "search_entities.json"},
+				{"prop=pageprops", "pageprops_entities.json"},
+			}))
+
+			client.RawText = test.rawText
+
+			articles, err := client.Search(context.Background(), "death star")
+
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			if len(articles) != 1 {
+				t.Fatalf("Search() returned %d articles, want 1", len(articles))
+			}
+
+			if articles[0].Title != test.wantTitle {
+				t.Errorf("Title = %q, want %q", articles[0].Title, test.wantTitle)
+			}
+
+			if articles[0].Snippet != test.wantSnippet {
+				t.Errorf("Snippet = %q, want %q", articles[0].Snippet, test.wantSnippet)
+			}
+		})
+	}
+}
+
+func TestIntitleQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		want  string
+	}{
+		{"words", "go gopher", "intitle:go intitle:gopher"},
+		{"quoted phrase", `"machine learning" tutorial`, `intitle:"machine learning" intitle:tutorial`},
+		{"operator kept", "intitle:golang mascot", "intitle:golang intitle:mascot"},
+		{"operator with phrase", `insource:"go func" gopher`, `insource:"go func" intitle:gopher`},
+		{"negated operator", "gopher -intitle:mascot", "intitle:gopher -intitle:mascot"},
+		{"unterminated quote", `gopher "go mascot`, `intitle:gopher intitle:"go mascot`},
+		{"extra spaces", "  go   gopher ", "intitle:go intitle:gopher"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := intitleQuery(test.topic); got != test.want {
+				t.Errorf("intitleQuery(%q) = %q, want %q", test.topic, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSearchDeduplication(t *testing.T) {
+	tests := []struct {
+		name      string
+		redirects string
+	}{
+		// The redirect is resolved to its target's page id
+		{"resolved redirect", "redirects_resolved.json"},
+		// Only the redirect title the first result was matched through gives the duplicate away
+		{"redirect title", "redirects_unresolved.json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serveFixtures(t, [][2]string{
+				{"list=search", "search_duplicates.json"},
+				{"redirects=", test.redirects},
+			}))
+
+			articles, err := client.Search(context.Background(), "uk", WithDeduplication())
+
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			titles := make([]string, 0, len(articles))
+
+			for _, article := range articles {
+				titles = append(titles, article.Title)
+			}
+
+			if got, want := strings.Join(titles, "|"), "United Kingdom|Great Britain"; got != want {
+				t.Errorf("Search() titles = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWithHTMLHighlightValidatesTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		wantErr bool
+	}{
+		{"mark", false},
+		{"my-highlight", false},
+		{"h1", false},
+		{"", true},
+		{"mark class=x", true},
+		{`b onmouseover="alert(1)"`, true},
+		{"<mark>", true},
+		{"1b", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			client := newTestClient(t, serveFixture(t, "search_machine_learning.json"))
+
+			_, err := client.Search(context.Background(), "machine learning", WithHTMLHighlight(test.tag))
+
+			if (err != nil) != test.wantErr {
+				t.Errorf("Search() with tag %q error = %v, want error %t", test.tag, err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// headingPattern matches a heading line of a plain-text extract requested with exsectionformat=wiki,
+// e.g. "== History ==".
+var headingPattern = regexp.MustCompile(`^(={2,6})\s*(.*?)\s*={2,6}$`)
+
+// Section is a section of an article's full extract.
+type Section struct {
+	// Title is the section heading. It is empty for the lead section.
+	Title string `json:"title"`
+
+	// Level is the heading level as in wikitext: 2 for a top-level section marked "== Title ==", 3 for a
+	// subsection marked "=== Title ===" and so on. The lead section has level 0.
+	Level int `json:"level"`
+
+	// Text is the plain text of the section, up to the next heading of any level.
+	Text string `json:"text"`
+}
+
+// GetStructuredExtract returns the full extract of the article with the given page id, split into sections
+// using DefaultClient.
+func GetStructuredExtract(pageId int) ([]Section, error) {
+	return DefaultClient.GetStructuredExtract(context.Background(), pageId)
+}
+
+// GetStructuredExtract returns the full plain-text extract of the article with the given page id, split into its
+// lead and sections. Each section's level is the number of "=" marking its heading, so the sections can be
+// nested into an outline. Sections without text, such as those holding only a table, are kept with an
+// empty Text so that the outline stays complete.
+func (c *Client) GetStructuredExtract(ctx context.Context, pageId int) ([]Section, error) {
+	page, err := c.getFullExtract(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return splitSections(page.Extract), nil
+}
+
+// StructuredArticle is an article's intro together with its opening sections, as returned by GetArticleTop.
+type StructuredArticle struct {
+	Title  string `json:"title"`
+	PageID int    `json:"pageid"`
+	URL    string `json:"url,omitempty"`
+	Intro  string `json:"intro"`
+
+	// Sections holds the opening top-level sections, each followed by its subsections.
+	Sections []Section `json:"sections"`
+}
+
+// GetArticleTop returns the intro and the first sections of the article with the given page id using DefaultClient.
+func GetArticleTop(pageId int, sections int) (*StructuredArticle, error) {
+	return DefaultClient.GetArticleTop(context.Background(), pageId, sections)
+}
+
+// GetArticleTop returns the intro and the first given number of top-level sections, with their subsections, of the
+// article with the given page id, in a single request unless the extract is too long for one response. An article
+// with fewer sections is returned with all of them.
+func (c *Client) GetArticleTop(ctx context.Context, pageId int, sections int) (*StructuredArticle, error) {
+	page, err := c.getFullExtract(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	article := &StructuredArticle{
+		Title:    c.decodeText(page.Title),
+		PageID:   page.Pageid,
+		URL:      page.FullURL,
+		Sections: make([]Section, 0),
+	}
+
+	topLevel := 0
+
+	for _, section := range splitSections(page.Extract) {
+		if section.Level == 0 {
+			article.Intro = section.Text
+			continue
+		}
+
+		// The top-level sections are the shallowest ones, which aren't always marked "=="
+		if topLevel == 0 || section.Level <= article.Sections[0].Level {
+			topLevel++
+		}
+
+		if topLevel > sections {
+			break
+		}
+
+		article.Sections = append(article.Sections, section)
+	}
+
+	return article, nil
+}
+
+// getFullExtract fetches the url and the cleaned full plain-text extract, with wiki-style heading lines, of the
+// article with the given page id.
+func (c *Client) getFullExtract(ctx context.Context, pageId int) (extractPage, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info|extracts"
+	params["inprop"] = "url"
+	params["explaintext"] = ""
+	params["exsectionformat"] = "wiki"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	extractResponse, err := c.getExtracts(ctx, params)
+
+	if err != nil {
+		return extractPage{}, err
+	}
+
+	if len(extractResponse.Query.Pages) == 0 || extractResponse.Query.Pages[0].Missing {
+		return extractPage{}, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := extractResponse.Query.Pages[0]
+
+	if page.Extract == "" {
+		return extractPage{}, ErrNoExtract
+	}
+
+	page.Extract = c.cleanExtract(page.Extract)
+
+	return page, nil
+}
+
+// splitSections splits a plain-text extract with wiki-style heading lines into sections. The lead section is
+// omitted when the extract starts with a heading.
+func splitSections(extract string) []Section {
+	sections := make([]Section, 0)
+	current := Section{}
+	lines := make([]string, 0)
+
+	flush := func() {
+		current.Text = strings.TrimSpace(strings.Join(lines, "\n"))
+
+		if current.Level > 0 || current.Text != "" {
+			sections = append(sections, current)
+		}
+
+		lines = lines[:0]
+	}
+
+	for _, line := range strings.Split(extract, "\n") {
+		match := headingPattern.FindStringSubmatch(strings.TrimSpace(line))
+
+		if match == nil {
+			lines = append(lines, line)
+			continue
+		}
+
+		flush()
+
+		current = Section{Title: match[2], Level: len(match[1])}
+	}
+
+	flush()
+
+	return sections
+}
@@ -0,0 +1,60 @@
+package dwiki
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetStructuredExtractFollowsContinuation(t *testing.T) {
+	var recorder queryRecorder
+
+	client := newTestClient(t, recorder.wrap(serveFixtures(t, [][2]string{
+		{"excontinue=1", "extracts_full_chunk2.json"},
+		{"excontinue=2", "extracts_full_chunk3.json"},
+		{"action=query", "extracts_full_chunk1.json"},
+	})))
+
+	sections, err := client.GetStructuredExtract(context.Background(), 25039021)
+
+	if err != nil {
+		t.Fatalf("GetStructuredExtract() error = %v", err)
+	}
+
+	want := []Section{
+		{Title: "", Level: 0, Text: "Go is a high-level general-purpose programming language."},
+		{Title: "History", Level: 2, Text: "Go was designed at Google in 2007 to improve programming productivity."},
+		{Title: "Design", Level: 2, Text: "Go is statically typed and compiled."},
+	}
+
+	if !reflect.DeepEqual(sections, want) {
+		t.Errorf("GetStructuredExtract() = %#v, want %#v", sections, want)
+	}
+
+	if len(recorder.queries) != 3 {
+		t.Fatalf("made %d requests, want 3", len(recorder.queries))
+	}
+
+	// Every continuation parameter is sent back, not only excontinue
+	for i, query := range recorder.queries[1:] {
+		if query.Get("continue") != "||" {
+			t.Errorf("continuation request %d has continue = %q, want %q", i+1, query.Get("continue"), "||")
+		}
+	}
+}
+
+func TestIntroExtractIgnoresContinuation(t *testing.T) {
+	var recorder queryRecorder
+
+	client := newTestClient(t, recorder.wrap(serveFixture(t, "extracts_full_chunk1.json")))
+
+	_, err := client.GetArticleDetails(context.Background(), 25039021)
+
+	if err != nil {
+		t.Fatalf("GetArticleDetails() error = %v", err)
+	}
+
+	if len(recorder.queries) != 1 {
+		t.Errorf("made %d requests, want 1", len(recorder.queries))
+	}
+}
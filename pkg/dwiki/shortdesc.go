@@ -0,0 +1,95 @@
+package dwiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// shortDescriptionPattern matches the {{Short description|...}} template in wikitext, capturing its value.
+var shortDescriptionPattern = regexp.MustCompile(`(?i)\{\{\s*short[ _]description\s*\|\s*([^|}]*)`)
+
+// GetShortDescriptionTemplate returns the value of the article's {{Short description}} template using DefaultClient.
+func GetShortDescriptionTemplate(pageId int) (string, error) {
+	return DefaultClient.GetShortDescriptionTemplate(context.Background(), pageId)
+}
+
+// GetShortDescriptionTemplate returns the short description set on the article with the given page id by its
+// {{Short description|...}} template, read from the wikitext of its lead section. That is the description editors
+// maintain on the page, which may differ from the one in Wikidata. When the article has no such template, the
+// Wikidata description is returned instead, or an empty string if there is none either. A template value of
+// "none", which editors use to mark that no description is wanted, returns an empty string.
+func (c *Client) GetShortDescriptionTemplate(ctx context.Context, pageId int) (string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "revisions"
+	params["rvprop"] = "content"
+	params["rvslots"] = "main"
+	params["rvsection"] = "0"
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	var revisionsResponse revisionsResponse
+
+	err := c.getJSON(ctx, params, &revisionsResponse)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(revisionsResponse.Query.Pages) == 0 || revisionsResponse.Query.Pages[0].Missing {
+		return "", fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := revisionsResponse.Query.Pages[0]
+
+	if len(page.Revisions) == 0 {
+		return "", errors.New("no revisions found")
+	}
+
+	match := shortDescriptionPattern.FindStringSubmatch(page.Revisions[0].Slots["main"].Content)
+
+	if match != nil {
+		description := strings.TrimSpace(match[1])
+
+		if strings.EqualFold(description, "none") {
+			return "", nil
+		}
+
+		return description, nil
+	}
+
+	return c.getCentralDescription(ctx, pageId)
+}
+
+// getCentralDescription returns the Wikidata description of the article with the given page id, or an empty
+// string if it has none.
+func (c *Client) getCentralDescription(ctx context.Context, pageId int) (string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "description"
+	params["descprefersource"] = "central"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	var descriptionsResponse descriptionsResponse
+
+	err := c.getJSON(ctx, params, &descriptionsResponse)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, page := range descriptionsResponse.Query.Pages {
+		if page.Pageid == pageId {
+			return c.decodeText(page.Description), nil
+		}
+	}
+
+	return "", nil
+}
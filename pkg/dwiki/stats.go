@@ -0,0 +1,61 @@
+package dwiki
+
+import "sync/atomic"
+
+// Stats holds counters of a Client's activity since it was created.
+type Stats struct {
+	// Requests is the number of HTTP requests sent, including retries.
+	Requests int64
+
+	// Retries is the number of requests repeated after the API throttled them.
+	Retries int64
+
+	// CacheHits and CacheMisses count the lookups in the client's Cache.
+	CacheHits   int64
+	CacheMisses int64
+
+	// NetworkErrors counts requests that failed before a complete response was read.
+	NetworkErrors int64
+
+	// HTTPErrors counts responses with an unsuccessful status code.
+	HTTPErrors int64
+
+	// APIErrors counts responses whose body reported an API error or, with FailOnWarnings, warnings.
+	APIErrors int64
+
+	// DecodeErrors counts responses that couldn't be parsed.
+	DecodeErrors int64
+
+	// SharedRequests counts requests that, with ShareRequests, were answered by an identical one already in
+	// flight instead of calling the API.
+	SharedRequests int64
+}
+
+type statsCounters struct {
+	requests       atomic.Int64
+	retries        atomic.Int64
+	cacheHits      atomic.Int64
+	cacheMisses    atomic.Int64
+	networkErrors  atomic.Int64
+	httpErrors     atomic.Int64
+	apiErrors      atomic.Int64
+	decodeErrors   atomic.Int64
+	sharedRequests atomic.Int64
+}
+
+// Stats returns a snapshot of the client's counters. It is safe to call while requests are in flight.
+func (c *Client) Stats() Stats {
+	counters := &c.state().stats
+
+	return Stats{
+		Requests:       counters.requests.Load(),
+		Retries:        counters.retries.Load(),
+		CacheHits:      counters.cacheHits.Load(),
+		CacheMisses:    counters.cacheMisses.Load(),
+		NetworkErrors:  counters.networkErrors.Load(),
+		HTTPErrors:     counters.httpErrors.Load(),
+		APIErrors:      counters.apiErrors.Load(),
+		DecodeErrors:   counters.decodeErrors.Load(),
+		SharedRequests: counters.sharedRequests.Load(),
+	}
+}
@@ -0,0 +1,136 @@
+package dwiki
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// tableTagPattern matches an opening or closing table tag in parsed article HTML, capturing the class
+	// attribute of opening tags.
+	tableTagPattern = regexp.MustCompile(`<table(?:\s[^>]*?class="([^"]*)")?[^>]*>|</table>`)
+
+	// tableRowPattern matches a table row, capturing its content.
+	tableRowPattern = regexp.MustCompile(`(?s)<tr[^>]*>(.*?)</tr>`)
+
+	// tableCellPattern matches a header or data cell, capturing its content.
+	tableCellPattern = regexp.MustCompile(`(?s)<t[hd](?:\s[^>]*)?>(.*?)</t[hd]>`)
+
+	// referencePattern matches a footnote marker in parsed article HTML.
+	referencePattern = regexp.MustCompile(`(?s)<sup[^>]*class="[^"]*reference[^"]*"[^>]*>.*?</sup>`)
+)
+
+// Table is a data table of an article as rows of cells, header rows included, as returned by GetArticleTables.
+type Table [][]string
+
+// GetArticleTables returns the data tables of the article with the given page id using DefaultClient.
+func GetArticleTables(pageId int) ([]Table, error) {
+	return DefaultClient.GetArticleTables(context.Background(), pageId)
+}
+
+// GetArticleTables returns the data tables of the article with the given page id, those with the wikitable or
+// sortable class, in page order. Layout tables such as infoboxes and navigation boxes are skipped. Each cell holds
+// the plain text of the cell without footnote markers, and a cell spanning several rows or columns appears once,
+// in the first of them. Tables nested inside a data table are left out of its cells. The result is empty if the
+// article has no data tables. It makes a single request for the parsed article HTML.
+func (c *Client) GetArticleTables(ctx context.Context, pageId int) ([]Table, error) {
+	params := make(map[string]string)
+
+	params["action"] = "parse"
+	params["pageid"] = strconv.Itoa(pageId)
+	params["prop"] = "text"
+	params["disabletoc"] = "1"
+	params["disableeditsection"] = "1"
+	params["format"] = "json"
+
+	var parseResponse parseResponse
+
+	err := c.getJSON(ctx, params, &parseResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return articleTables(parseResponse.Parse.Text), nil
+}
+
+// articleTables finds the outermost data tables in the article HTML and splits them into rows and cells.
+func articleTables(text string) []Table {
+	tables := make([]Table, 0)
+
+	depth := 0
+	start := -1
+
+	// Strip tables nested in the current data table from its content as they close
+	var content strings.Builder
+	last := 0
+
+	for _, match := range tableTagPattern.FindAllStringSubmatchIndex(text, -1) {
+		if !strings.HasPrefix(text[match[0]:match[1]], "</") {
+			if depth == 0 && match[2] >= 0 && isDataTable(text[match[2]:match[3]]) {
+				start = match[1]
+				last = start
+				content.Reset()
+			}
+
+			if depth == 1 && start >= 0 {
+				content.WriteString(text[last:match[0]])
+			}
+
+			depth++
+			continue
+		}
+
+		if depth == 0 {
+			continue
+		}
+
+		depth--
+
+		if start < 0 {
+			continue
+		}
+
+		if depth == 0 {
+			content.WriteString(text[last:match[0]])
+			tables = append(tables, tableRows(content.String()))
+			start = -1
+		} else if depth == 1 {
+			last = match[1]
+		}
+	}
+
+	return tables
+}
+
+// isDataTable reports whether a table with the given class attribute holds data rather than layout.
+func isDataTable(class string) bool {
+	for _, name := range strings.Fields(class) {
+		if name == "wikitable" || name == "sortable" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tableRows splits the HTML content of a table into the plain text of its cells, leaving out empty rows.
+func tableRows(content string) Table {
+	table := make(Table, 0)
+
+	for _, row := range tableRowPattern.FindAllStringSubmatch(content, -1) {
+		cells := make([]string, 0)
+
+		for _, cell := range tableCellPattern.FindAllStringSubmatch(row[1], -1) {
+			cells = append(cells, strings.Join(strings.Fields(stripTags(referencePattern.ReplaceAllString(cell[1], ""))), " "))
+		}
+
+		if len(cells) > 0 {
+			table = append(table, cells)
+		}
+	}
+
+	return table
+}
@@ -0,0 +1,87 @@
+package dwiki
+
+import (
+	"context"
+	"strconv"
+)
+
+// GetTalkPage fetches the talk page of the article with the given title using DefaultClient.
+func GetTalkPage(title string) (*Article, bool, error) {
+	return DefaultClient.GetTalkPage(context.Background(), title)
+}
+
+// GetTalkPage fetches the talk page of the page with the given title and reports whether it exists. The talk page
+// is looked up through the page itself, so that a title in another namespace, e.g. "Category:Go", gets its own
+// talk page, "Category talk:Go", costing one extra request. A title that is already a talk page returns that
+// page. A talk page that doesn't exist, or a page that has none, is not an error; the article is then nil. Talk
+// pages often open with a section rather than an intro, so the returned article's Extract and Preview may be
+// empty.
+func (c *Client) GetTalkPage(ctx context.Context, title string) (*Article, bool, error) {
+	talkId, err := c.getTalkId(ctx, title)
+
+	if err != nil || talkId == 0 {
+		return nil, false, err
+	}
+
+	params := make(map[string]string)
+
+	params["pageids"] = strconv.Itoa(talkId)
+
+	page, err := c.queryPage(ctx, params)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if page.Missing || page.Invalid {
+		return nil, false, nil
+	}
+
+	article := &Article{
+		Title:        c.decodeText(page.Title),
+		PageID:       page.Pageid,
+		URL:          page.FullURL,
+		DisplayTitle: page.DisplayTitle,
+		Length:       page.Length,
+		Extract:      page.Extract,
+		Preview:      c.preview(page.Extract, page.cut),
+	}
+
+	return article, true, nil
+}
+
+// getTalkId returns the page id of the talk page of the page with the given title, the page's own id when it is a
+// talk page, or 0 when there is no such talk page.
+func (c *Client) getTalkId(ctx context.Context, title string) (int, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "info"
+	params["inprop"] = "talkid"
+	params["titles"] = c.normalizeTitle(title)
+	c.followRedirects(params)
+	params["format"] = "json"
+
+	var infoResponse infoResponse
+
+	err := c.getJSON(ctx, params, &infoResponse)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, page := range infoResponse.Query.Pages {
+		if !page.exists() {
+			continue
+		}
+
+		// Talk namespaces are the odd ones, each following its subject namespace
+		if page.Ns > 0 && page.Ns%2 == 1 {
+			return page.Pageid, nil
+		}
+
+		return page.Talkid, nil
+	}
+
+	return 0, nil
+}
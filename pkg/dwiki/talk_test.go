@@ -0,0 +1,56 @@
+package dwiki
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetTalkPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		title      string
+		info       string
+		wantExists bool
+	}{
+		{"category", "Category:Go (programming language)", "info_talkid_category.json", true},
+		{"already a talk page", "Category talk:Go (programming language)", "info_talkid_talk.json", true},
+		{"no talk page", "Category:Gophers", "info_talkid_none.json", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var recorder queryRecorder
+
+			client := newTestClient(t, recorder.wrap(serveFixtures(t, [][2]string{
+				{"inprop=talkid", test.info},
+				{"pageids=4146390", "extracts_category_talk.json"},
+			})))
+
+			article, exists, err := client.GetTalkPage(context.Background(), test.title)
+
+			if err != nil {
+				t.Fatalf("GetTalkPage() error = %v", err)
+			}
+
+			if exists != test.wantExists {
+				t.Fatalf("GetTalkPage() exists = %t, want %t", exists, test.wantExists)
+			}
+
+			if got := recorder.queries[0].Get("titles"); got != test.title {
+				t.Errorf("GetTalkPage() looked up %q, want %q", got, test.title)
+			}
+
+			if !test.wantExists {
+				if article != nil || len(recorder.queries) != 1 {
+					t.Errorf("GetTalkPage() = %v after %d requests, want nil after 1", article, len(recorder.queries))
+				}
+
+				return
+			}
+
+			if article.Title != "Category talk:Go (programming language)" {
+				t.Errorf("GetTalkPage() title = %q, want %q", article.Title, "Category talk:Go (programming language)")
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package dwiki
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// templateNamespace is the id of the Template namespace.
+const templateNamespace = 10
+
+type templatesResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Continue      struct {
+		Tlcontinue string `json:"tlcontinue"`
+		Continue   string `json:"continue"`
+	} `json:"continue"`
+	Query struct {
+		Pages []struct {
+			Pageid    int    `json:"pageid"`
+			Title     string `json:"title"`
+			Missing   bool   `json:"missing"`
+			Templates []struct {
+				Ns    int    `json:"ns"`
+				Title string `json:"title"`
+			} `json:"templates"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// GetArticleTemplates returns the templates the article with the given page id uses using DefaultClient.
+func GetArticleTemplates(pageId int, limit int) ([]string, error) {
+	return DefaultClient.GetArticleTemplates(context.Background(), pageId, limit)
+}
+
+// GetArticleTemplates returns up to limit titles of the templates the article with the given page id transcludes,
+// with their "Template:" prefix, in title order, e.g. its infobox, navigation boxes and maintenance tags. Templates
+// used through other templates are included, as the API lists every page the article transcludes, but pages of
+// other namespaces, such as modules, are not. A limit of 0 or less returns all of them, following continuation
+// until all have been read. It returns ErrPageNotFound if there is no page with the id.
+func (c *Client) GetArticleTemplates(ctx context.Context, pageId int, limit int) ([]string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "templates"
+	params["tlnamespace"] = strconv.Itoa(templateNamespace)
+	params["tllimit"] = "max"
+	params["pageids"] = strconv.Itoa(pageId)
+	params["format"] = "json"
+
+	templates := make([]string, 0)
+
+	for {
+		var templatesResponse templatesResponse
+
+		err := c.getJSON(ctx, params, &templatesResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(templatesResponse.Query.Pages) == 0 || templatesResponse.Query.Pages[0].Missing {
+			return nil, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+		}
+
+		for _, template := range templatesResponse.Query.Pages[0].Templates {
+			templates = append(templates, c.decodeText(template.Title))
+
+			if limit > 0 && len(templates) == limit {
+				return templates, nil
+			}
+		}
+
+		if templatesResponse.Continue.Tlcontinue == "" {
+			break
+		}
+
+		params["tlcontinue"] = templatesResponse.Continue.Tlcontinue
+	}
+
+	return templates, nil
+}
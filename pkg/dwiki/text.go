@@ -0,0 +1,294 @@
+package dwiki
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	citationPattern    = regexp.MustCompile(`\[(?:\d+|[a-z]|note \d+|citation needed)\]`)
+	doubleSpacePattern = regexp.MustCompile(`[ \t]{2,}`)
+	spacePunctPattern  = regexp.MustCompile(` +([.,;:])`)
+	searchMatchPattern = regexp.MustCompile(`</?span[^>]*>`)
+	tagPattern         = regexp.MustCompile(`<[^>]*>`)
+
+	// ipaPattern matches an IPA transcription between slashes, e.g. "/ˈwɪkiˌpiːdiə/", standing apart from the
+	// words around it, so that a slash inside a word such as "TCP/IP" or a date range such as "1990/91" doesn't.
+	ipaPattern = regexp.MustCompile(`(?:^|\s)/[^/\s][^/]*/(?:$|[\s;,])`)
+)
+
+// Truncate shortens text to at most length characters, cutting at the last word boundary and appending "...".
+// Text that already fits is returned unchanged, and a negative length disables truncation. A single word
+// longer than length is cut mid-word.
+func Truncate(text string, length int) string {
+	return truncate(text, length, DefaultEllipsis)
+}
+
+// truncate shortens text like Truncate, appending the given ellipsis instead.
+func truncate(text string, length int, ellipsis string) string {
+	runes := []rune(text)
+
+	if length < 0 || len(runes) <= length {
+		return text
+	}
+
+	cut := length
+
+	// Back up to the last whitespace so that a word isn't split, unless the text has none to back up to
+	for cut > 0 && !unicode.IsSpace(runes[cut]) {
+		cut--
+	}
+
+	if cut == 0 {
+		cut = length
+	}
+
+	return strings.TrimSpace(string(runes[:cut])) + ellipsis
+}
+
+// TruncateRatio shortens text to the given fraction of its length, e.g. 0.25 for a quarter, cutting at a word
+// boundary as Truncate does. A ratio of 1 or more, or of 0 or less, returns the text unchanged.
+func TruncateRatio(text string, ratio float64) string {
+	if ratio <= 0 || ratio >= 1 {
+		return text
+	}
+
+	return Truncate(text, int(float64(len([]rune(text)))*ratio))
+}
+
+// StripCitations removes bracketed reference markers such as "[1]", "[a]", "[note 2]" and "[citation needed]"
+// from text, collapsing the double spaces they leave behind.
+func StripCitations(text string) string {
+	text = citationPattern.ReplaceAllString(text, "")
+	text = doubleSpacePattern.ReplaceAllString(text, " ")
+	text = spacePunctPattern.ReplaceAllString(text, "$1")
+
+	return text
+}
+
+// stripSearchMatches removes the <span class="searchmatch"> markup the search API wraps matched terms in.
+func stripSearchMatches(snippet string) string {
+	return searchMatchPattern.ReplaceAllString(snippet, "")
+}
+
+// searchMatchTermPattern matches a term the search API marked as matched, capturing the term.
+var searchMatchTermPattern = regexp.MustCompile(`(?s)<span class="searchmatch">(.*?)</span>`)
+
+// highlightSearchMatches replaces the <span class="searchmatch"> markup the search API wraps matched terms in
+// with the given markers, removing any other span markup.
+func highlightSearchMatches(snippet, before, after string) string {
+	marked := searchMatchTermPattern.ReplaceAllStringFunc(snippet, func(match string) string {
+		return before + searchMatchTermPattern.FindStringSubmatch(match)[1] + after
+	})
+
+	return stripSearchMatches(marked)
+}
+
+// abbreviations lists lowercase words, without their final period, that are commonly followed by a period
+// without ending the sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "st": true, "jr": true, "sr": true,
+	"vs": true, "etc": true, "e.g": true, "i.e": true, "approx": true, "no": true, "vol": true,
+	"inc": true, "ltd": true, "co": true, "corp": true, "gen": true, "gov": true, "sen": true, "rep": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true, "sep": true,
+	"sept": true, "oct": true, "nov": true, "dec": true, "c": true, "ca": true, "fl": true,
+}
+
+// firstSentence returns the first sentence of text. A period only ends the sentence when it is followed by
+// whitespace and a capital letter or digit, is outside parentheses, and doesn't end an abbreviation such as
+// "Dr.", an initial such as "J.", or a dotted acronym such as "U.S.".
+func firstSentence(text string) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	depth := 0
+
+	for i, r := range runes {
+		switch r {
+		case '(', '[':
+			depth++
+			continue
+		case ')', ']':
+			if depth > 0 {
+				depth--
+			}
+			continue
+		case '.', '!', '?':
+		default:
+			continue
+		}
+
+		if depth > 0 {
+			continue
+		}
+
+		if i == len(runes)-1 {
+			return text
+		}
+
+		// The sentence must be followed by whitespace and then the start of a new one
+		if !unicode.IsSpace(runes[i+1]) {
+			continue
+		}
+
+		next := i + 1
+
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			next++
+		}
+
+		if next < len(runes) && !unicode.IsUpper(runes[next]) && !unicode.IsDigit(runes[next]) {
+			continue
+		}
+
+		if r == '.' {
+			// Find the word that the period ends
+			start := i
+
+			for start > 0 && !unicode.IsSpace(runes[start-1]) && runes[start-1] != '(' {
+				start--
+			}
+
+			word := strings.ToLower(string(runes[start:i]))
+
+			if abbreviations[word] || len([]rune(word)) == 1 || strings.Contains(word, ".") {
+				continue
+			}
+		}
+
+		return strings.TrimSpace(string(runes[:i+1]))
+	}
+
+	return text
+}
+
+// NormalizeParagraphs trims each paragraph of text and separates them with exactly one blank line, whether they
+// were separated by a single newline or by several blank lines.
+func NormalizeParagraphs(text string) string {
+	return strings.Join(splitParagraphs(text), "\n\n")
+}
+
+// CutAtFirstHeading returns the part of a plain-text extract before its first heading line, such as
+// "== History ==", so that only the lead remains even when an intro extract runs past it. Text without a
+// heading is returned whole, with trailing whitespace trimmed.
+func CutAtFirstHeading(text string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if headingPattern.MatchString(strings.TrimSpace(line)) {
+			lines = lines[:i]
+			break
+		}
+	}
+
+	return strings.TrimRightFunc(strings.Join(lines, "\n"), unicode.IsSpace)
+}
+
+// pronunciationMarkers are the strings that, besides an IPA transcription, identify a parenthetical as a
+// pronunciation guide: the listen icon and the usual wording.
+var pronunciationMarkers = []string{"ⓘ", "pronounced", "pronunciation", "listen", "IPA:"}
+
+// StripPronunciation removes the pronunciation parenthetical that often follows the title phrase at the start of
+// an intro, e.g. the "(/ˈwɪkiˌpiːdiə/; ...)" in "Wikipedia (/ˈwɪkiˌpiːdiə/; ...) is an online encyclopedia".
+// To leave meaningful parentheticals alone, only the first parenthetical of the first sentence is considered,
+// and only when it contains an IPA transcription or pronunciation wording.
+func StripPronunciation(text string) string {
+	runes := []rune(text)
+	open := -1
+
+	for i, r := range runes {
+		if r == '(' {
+			open = i
+			break
+		}
+
+		// The parenthetical must belong to the title phrase, before the first sentence ends
+		if r == '.' || r == '\n' {
+			return text
+		}
+	}
+
+	if open <= 0 {
+		return text
+	}
+
+	depth := 0
+	end := -1
+
+	for i := open; i < len(runes); i++ {
+		if runes[i] == '(' {
+			depth++
+		} else if runes[i] == ')' {
+			depth--
+
+			if depth == 0 {
+				end = i
+				break
+			}
+		}
+	}
+
+	if end < 0 {
+		return text
+	}
+
+	inner := string(runes[open+1 : end])
+	marked := ipaPattern.MatchString(inner)
+
+	for _, marker := range pronunciationMarkers {
+		if strings.Contains(inner, marker) {
+			marked = true
+			break
+		}
+	}
+
+	if !marked {
+		return text
+	}
+
+	return strings.TrimRight(string(runes[:open]), " ") + string(runes[end+1:])
+}
+
+// decodeText decodes HTML entities such as "&amp;" and "&#39;" in text returned by the API, unless the
+// client wants the raw text.
+func (c *Client) decodeText(text string) string {
+	if c.RawText {
+		return text
+	}
+
+	return html.UnescapeString(text)
+}
+
+// stripTags reduces an HTML fragment to plain text by removing its tags and decoding its entities.
+func stripTags(fragment string) string {
+	return strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(fragment, "")))
+}
+
+// NormalizeTitle turns a topic as typed into the form of an article title: underscores become spaces, runs of
+// whitespace are collapsed and trimmed, and the first letter is capitalized, e.g. "  united_kingdom " becomes
+// "United kingdom". Titles are case-sensitive after the first letter, so the rest is left as is. The titles given
+// to GetSummaryForTitle, ArticleExists, FindRedirectTarget and GetRESTCard are normalized this way before being
+// fetched.
+func NormalizeTitle(title string) string {
+	title = strings.Join(strings.Fields(strings.ReplaceAll(title, "_", " ")), " ")
+
+	r, size := utf8.DecodeRuneInString(title)
+
+	if size == 0 {
+		return title
+	}
+
+	return string(unicode.ToUpper(r)) + title[size:]
+}
+
+// normalizeTitle applies NormalizeTitle to a title about to be fetched, leaving its first letter alone on
+// Wiktionary, whose titles are case-sensitive throughout.
+func (c *Client) normalizeTitle(title string) string {
+	if c.Project == "wiktionary" {
+		return strings.Join(strings.Fields(strings.ReplaceAll(title, "_", " ")), " ")
+	}
+
+	return NormalizeTitle(title)
+}
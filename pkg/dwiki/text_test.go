@@ -0,0 +1,36 @@
+package dwiki
+
+import "testing"
+
+func TestStripPronunciation(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			"ipa",
+			"Wikipedia (/ˌwɪkɪˈpiːdiə/ wik-ih-PEE-dee-ə) is a free online encyclopedia.",
+			"Wikipedia is a free online encyclopedia.",
+		},
+		{
+			"ipa with spaces and a note",
+			"NASA (/ˈnæsə/; an acronym) is an independent agency.",
+			"NASA is an independent agency.",
+		},
+		{"listen icon", "Paris (French pronunciation: [paʁi] ⓘ) is the capital of France.", "Paris is the capital of France."},
+		{"slash inside a word", "TCP (part of TCP/IP) is a transport protocol.", "TCP (part of TCP/IP) is a transport protocol."},
+		{"season", "The 1990 season (1990/91 in Europe) was the first.", "The 1990 season (1990/91 in Europe) was the first."},
+		{"and/or", "Gin (and/or genever) is a spirit.", "Gin (and/or genever) is a spirit."},
+		{"no parenthetical", "Go is a programming language.", "Go is a programming language."},
+		{"after the first sentence", "Go is a language. Its mascot (/ˈɡoʊfər/) is a gopher.", "Go is a language. Its mascot (/ˈɡoʊfər/) is a gopher."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := StripPronunciation(test.text); got != test.want {
+				t.Errorf("StripPronunciation() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package dwiki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wikidataEndpoint is the base url of Wikidata, which holds the items of every language edition.
+const wikidataEndpoint = "https://www.wikidata.org"
+
+// factProperties lists the Wikidata properties GetWikidataFacts reports, in the order they are looked up.
+var factProperties = []string{
+	"P31",   // instance of
+	"P17",   // country
+	"P131",  // located in the administrative territorial entity
+	"P36",   // capital
+	"P1082", // population
+	"P571",  // inception
+	"P112",  // founded by
+	"P159",  // headquarters location
+	"P569",  // date of birth
+	"P570",  // date of death
+	"P27",   // country of citizenship
+	"P106",  // occupation
+	"P856",  // official website
+}
+
+// maxFactValues is the most values of a property GetWikidataFacts lists.
+const maxFactValues = 3
+
+// wikidataClaim is a statement about a Wikidata item. Its value's form depends on its type, so it is decoded
+// once the type is known.
+type wikidataClaim struct {
+	Rank     string `json:"rank"`
+	Mainsnak struct {
+		Snaktype  string `json:"snaktype"`
+		Datavalue struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+		} `json:"datavalue"`
+	} `json:"mainsnak"`
+}
+
+type wikidataEntitiesResponse struct {
+	apiEnvelope
+
+	Entities map[string]struct {
+		ID     string                     `json:"id"`
+		Claims map[string][]wikidataClaim `json:"claims"`
+		Labels map[string]struct {
+			Language string `json:"language"`
+			Value    string `json:"value"`
+		} `json:"labels"`
+	} `json:"entities"`
+}
+
+type pagePropsResponse struct {
+	apiEnvelope
+
+	Batchcomplete bool `json:"batchcomplete"`
+	Query         struct {
+		Pages []struct {
+			Pageid      int               `json:"pageid"`
+			Title       string            `json:"title"`
+			Missing     bool              `json:"missing"`
+			PageProps   map[string]string `json:"pageprops"`
+			Description string            `json:"description"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// PageProps holds the commonly used page props of an article, as returned by GetPageProps. Props the article
+// doesn't have are left at their zero value.
+type PageProps struct {
+	// Disambiguation reports whether the page is a disambiguation page.
+	Disambiguation bool `json:"disambiguation"`
+
+	// WikidataID is the id of the linked Wikidata item, e.g. "Q42".
+	WikidataID string `json:"wikidataid,omitempty"`
+
+	// DisplayTitle is the title as HTML, set only when the article overrides how its title is displayed.
+	DisplayTitle string `json:"displaytitle,omitempty"`
+
+	// Description is the article's one-line short description.
+	Description string `json:"description,omitempty"`
+}
+
+// GetPageProps returns the page props of the article with the given page id using DefaultClient.
+func GetPageProps(pageId int) (*PageProps, error) {
+	return DefaultClient.GetPageProps(context.Background(), pageId)
+}
+
+// GetPageProps returns whether the article with the given page id is a disambiguation page, its Wikidata item id,
+// its display title override and its short description in a single request, instead of one request each with
+// IsDisambiguation, GetWikidataID and the like.
+func (c *Client) GetPageProps(ctx context.Context, pageId int) (*PageProps, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "pageprops|description"
+	params["ppprop"] = "disambiguation|wikibase_item|displaytitle"
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	var pagePropsResponse pagePropsResponse
+
+	err := c.getJSON(ctx, params, &pagePropsResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pagePropsResponse.Query.Pages) == 0 || pagePropsResponse.Query.Pages[0].Missing {
+		return nil, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := pagePropsResponse.Query.Pages[0]
+
+	// The disambiguation prop has an empty value, so only its presence counts
+	_, disambiguation := page.PageProps["disambiguation"]
+
+	return &PageProps{
+		Disambiguation: disambiguation,
+		WikidataID:     page.PageProps["wikibase_item"],
+		DisplayTitle:   page.PageProps["displaytitle"],
+		Description:    c.decodeText(page.Description),
+	}, nil
+}
+
+// getPageProps returns the requested page props of the page with the given id. Props the page doesn't have
+// are absent from the map.
+func (c *Client) getPageProps(ctx context.Context, pageId int, props string) (map[string]string, error) {
+	params := make(map[string]string)
+
+	params["action"] = "query"
+	params["prop"] = "pageprops"
+	params["ppprop"] = props
+	params["format"] = "json"
+	params["pageids"] = strconv.Itoa(pageId)
+
+	var pagePropsResponse pagePropsResponse
+
+	err := c.getJSON(ctx, params, &pagePropsResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pagePropsResponse.Query.Pages) == 0 || pagePropsResponse.Query.Pages[0].Missing {
+		return nil, fmt.Errorf("%w: %d", ErrPageNotFound, pageId)
+	}
+
+	page := pagePropsResponse.Query.Pages[0]
+
+	if page.PageProps == nil {
+		return map[string]string{}, nil
+	}
+
+	return page.PageProps, nil
+}
+
+// GetWikidataID returns the Wikidata item id of the article with the given page id using DefaultClient.
+func GetWikidataID(pageId int) (string, error) {
+	return DefaultClient.GetWikidataID(context.Background(), pageId)
+}
+
+// GetWikidataID returns the Wikidata item id, e.g. "Q42", of the article with the given page id. It returns an
+// empty string and a nil error when the article has no linked Wikidata item.
+func (c *Client) GetWikidataID(ctx context.Context, pageId int) (string, error) {
+	props, err := c.getPageProps(ctx, pageId, "wikibase_item")
+
+	if err != nil {
+		return "", err
+	}
+
+	return props["wikibase_item"], nil
+}
+
+// wikidata returns a copy of the client that queries Wikidata. The copy shares the client's state, such as its
+// rate limit.
+func (c *Client) wikidata() *Client {
+	c.state()
+
+	clone := *c
+	clone.Endpoints = []string{wikidataEndpoint}
+	clone.endpointErr = nil
+	return &clone
+}
+
+// GetWikidataFacts returns facts about the article with the given page id from its Wikidata item using
+// DefaultClient.
+func GetWikidataFacts(pageId int) (map[string]string, error) {
+	return DefaultClient.GetWikidataFacts(context.Background(), pageId)
+}
+
+// GetWikidataFacts returns facts about the article with the given page id taken from its Wikidata item, keyed by
+// property name, e.g. "instance of": "human" and "date of birth": "14 March 1879". It looks up a curated set of
+// common properties: instance of, country, location, capital, population, inception, founder, headquarters, dates
+// of birth and death, citizenship, occupation and official website. The property names and item values are the
+// labels in the client's language, falling back to English, and properties with several values list up to three,
+// comma separated. Properties the item doesn't have are left out, and an article without a Wikidata item has no
+// facts. It makes three requests: for the item id, the item and the labels.
+func (c *Client) GetWikidataFacts(ctx context.Context, pageId int) (map[string]string, error) {
+	id, err := c.GetWikidataID(ctx, pageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	facts := make(map[string]string)
+
+	if id == "" {
+		return facts, nil
+	}
+
+	wikidata := c.wikidata()
+
+	params := make(map[string]string)
+
+	params["action"] = "wbgetentities"
+	params["ids"] = id
+	params["props"] = "claims"
+	params["format"] = "json"
+
+	var entitiesResponse wikidataEntitiesResponse
+
+	err = wikidata.getJSON(ctx, params, &entitiesResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := entitiesResponse.Entities[id]
+
+	if !ok {
+		return facts, nil
+	}
+
+	// Collect the values of each property, with the ids of the items among them left to be labelled
+	values := make(map[string][]string)
+	labelled := make([]string, 0)
+
+	for _, property := range factProperties {
+		claims := item.Claims[property]
+
+		// Preferred statements, such as the current population, supersede the normal ones
+		rank := "normal"
+
+		for _, claim := range claims {
+			if claim.Rank == "preferred" {
+				rank = "preferred"
+			}
+		}
+
+		for _, claim := range claims {
+			if claim.Rank != rank || claim.Mainsnak.Snaktype != "value" || len(values[property]) == maxFactValues {
+				continue
+			}
+
+			value, isItem := formatClaimValue(claim)
+
+			if value == "" {
+				continue
+			}
+
+			if isItem {
+				labelled = append(labelled, value)
+			}
+
+			values[property] = append(values[property], value)
+		}
+
+		if len(values[property]) > 0 {
+			labelled = append(labelled, property)
+		}
+	}
+
+	if len(labelled) == 0 {
+		return facts, nil
+	}
+
+	labels, err := wikidata.getWikidataLabels(ctx, labelled, c.language())
+
+	if err != nil {
+		return nil, err
+	}
+
+	for property, propertyValues := range values {
+		for i, value := range propertyValues {
+			if label, ok := labels[value]; ok {
+				propertyValues[i] = label
+			}
+		}
+
+		name, ok := labels[property]
+
+		if !ok {
+			name = property
+		}
+
+		facts[name] = strings.Join(propertyValues, ", ")
+	}
+
+	return facts, nil
+}
+
+// getWikidataLabels returns the labels of the given Wikidata items and properties in the given language, falling
+// back to English, keyed by id. Ids without a label in either are absent from the map.
+func (c *Client) getWikidataLabels(ctx context.Context, ids []string, lang string) (map[string]string, error) {
+	labels := make(map[string]string)
+
+	for start := 0; start < len(ids); start += maxPageIds {
+		end := min(start+maxPageIds, len(ids))
+
+		params := make(map[string]string)
+
+		params["action"] = "wbgetentities"
+		params["ids"] = strings.Join(ids[start:end], "|")
+		params["props"] = "labels"
+		params["languages"] = lang + "|en"
+		params["format"] = "json"
+
+		var entitiesResponse wikidataEntitiesResponse
+
+		err := c.getJSON(ctx, params, &entitiesResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for id, entity := range entitiesResponse.Entities {
+			if label, ok := entity.Labels[lang]; ok {
+				labels[id] = label.Value
+			} else if label, ok := entity.Labels["en"]; ok {
+				labels[id] = label.Value
+			}
+		}
+	}
+
+	return labels, nil
+}
+
+// formatClaimValue returns the value of a statement as text, or an empty string for a type it doesn't handle.
+// A value that is another item is returned as its id, e.g. "Q5", and reported as such so it can be labelled.
+func formatClaimValue(claim wikidataClaim) (string, bool) {
+	value := claim.Mainsnak.Datavalue.Value
+
+	switch claim.Mainsnak.Datavalue.Type {
+	case "wikibase-entityid":
+		var entity struct {
+			ID string `json:"id"`
+		}
+
+		if json.Unmarshal(value, &entity) != nil {
+			return "", false
+		}
+
+		return entity.ID, true
+	case "string":
+		var text string
+
+		if json.Unmarshal(value, &text) != nil {
+			return "", false
+		}
+
+		return text, false
+	case "monolingualtext":
+		var text struct {
+			Text string `json:"text"`
+		}
+
+		if json.Unmarshal(value, &text) != nil {
+			return "", false
+		}
+
+		return text.Text, false
+	case "quantity":
+		var quantity struct {
+			Amount string `json:"amount"`
+		}
+
+		if json.Unmarshal(value, &quantity) != nil {
+			return "", false
+		}
+
+		return strings.TrimPrefix(quantity.Amount, "+"), false
+	case "time":
+		var moment struct {
+			Time      string `json:"time"`
+			Precision int    `json:"precision"`
+		}
+
+		if json.Unmarshal(value, &moment) != nil {
+			return "", false
+		}
+
+		return formatWikidataTime(moment.Time, moment.Precision), false
+	}
+
+	return "", false
+}
+
+// formatWikidataTime formats a Wikidata time value, e.g. "+1879-03-14T00:00:00Z", to its precision: a day
+// (11) as "14 March 1879", a month (10) as "March 1879" and a year (9) or coarser as "1879". Years before the
+// common era are marked "BCE".
+func formatWikidataTime(value string, precision int) string {
+	bce := strings.HasPrefix(value, "-")
+	value = strings.TrimLeft(value, "+-")
+
+	date, _, _ := strings.Cut(value, "T")
+	parts := strings.Split(date, "-")
+
+	if len(parts) != 3 {
+		return ""
+	}
+
+	year, yearErr := strconv.Atoi(parts[0])
+	month, monthErr := strconv.Atoi(parts[1])
+	day, dayErr := strconv.Atoi(parts[2])
+
+	if yearErr != nil {
+		return ""
+	}
+
+	if bce {
+		return strconv.Itoa(year) + " BCE"
+	}
+
+	// Less precise dates carry zeros in the parts they don't know
+	switch {
+	case precision < 10 || monthErr != nil || month < 1 || month > 12:
+		return strconv.Itoa(year)
+	case precision == 10 || dayErr != nil || day < 1:
+		return fmt.Sprintf("%s %d", time.Month(month), year)
+	default:
+		return fmt.Sprintf("%d %s %d", day, time.Month(month), year)
+	}
+}